@@ -0,0 +1,363 @@
+// bit set
+package bitset
+
+import (
+	"math/bits"
+
+	"github.com/zijiren233/gencontainer/set"
+)
+
+const wordSize = 64
+
+// BitSet is a set of non-negative integers stored as a bit array.
+// It grows automatically to hold the largest value inserted.
+//
+// Methods that overlap with set.Set use the same names (Insert, Remove,
+// Contain, Len, Union, ...), so a BitSet can replace a set.Set[uint] for
+// dense small integers.
+//
+// The zero BitSet is empty and ready for use.
+type BitSet struct {
+	words []uint64
+}
+
+// New returns an empty bit set with room for values below length.
+func New(length uint) *BitSet {
+	return &BitSet{words: make([]uint64, 0, wordsNeeded(length))}
+}
+
+// From returns a bit set holding the given values.
+func From(val ...uint) *BitSet {
+	b := &BitSet{}
+	for _, v := range val {
+		b.Set(v)
+	}
+	return b
+}
+
+// FromSet returns a bit set holding the values of s.
+func FromSet(s set.Set[uint]) *BitSet {
+	b := &BitSet{}
+	for v := range s {
+		b.Set(v)
+	}
+	return b
+}
+
+func wordsNeeded(length uint) int {
+	return int((length + wordSize - 1) / wordSize)
+}
+
+func (b *BitSet) grow(i uint) {
+	if n := int(i/wordSize) + 1; n > len(b.words) {
+		if n <= cap(b.words) {
+			b.words = b.words[:n]
+		} else {
+			words := make([]uint64, n, max(n, 2*cap(b.words)))
+			copy(words, b.words)
+			b.words = words
+		}
+	}
+}
+
+// Set sets bit i.
+func (b *BitSet) Set(i uint) *BitSet {
+	b.grow(i)
+	b.words[i/wordSize] |= 1 << (i % wordSize)
+	return b
+}
+
+// Clear clears bit i.
+func (b *BitSet) Clear(i uint) *BitSet {
+	if w := i / wordSize; w < uint(len(b.words)) {
+		b.words[w] &^= 1 << (i % wordSize)
+	}
+	return b
+}
+
+// Flip toggles bit i.
+func (b *BitSet) Flip(i uint) *BitSet {
+	b.grow(i)
+	b.words[i/wordSize] ^= 1 << (i % wordSize)
+	return b
+}
+
+// Test reports whether bit i is set.
+func (b *BitSet) Test(i uint) bool {
+	w := i / wordSize
+	return w < uint(len(b.words)) && b.words[w]&(1<<(i%wordSize)) != 0
+}
+
+// If value is not in the set, insert it and return true.
+// If value is in the set, return false.
+func (b *BitSet) Insert(val uint) bool {
+	if b.Test(val) {
+		return false
+	}
+	b.Set(val)
+	return true
+}
+
+// If value is in the set, return true.
+func (b *BitSet) Contain(val uint) bool {
+	return b.Test(val)
+}
+
+// Remove removes the value from the set.
+// If value is in the set, return true.
+// If value is not in the set, return false.
+func (b *BitSet) Remove(val uint) bool {
+	if !b.Test(val) {
+		return false
+	}
+	b.Clear(val)
+	return true
+}
+
+func (b *BitSet) Push(val ...uint) *BitSet {
+	for _, v := range val {
+		b.Set(v)
+	}
+	return b
+}
+
+// Count returns the number of set bits.
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Len returns the number of values in the set. It is the same as Count.
+func (b *BitSet) Len() int {
+	return b.Count()
+}
+
+func (b *BitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ClearAll clears every bit, keeping the allocated storage.
+func (b *BitSet) ClearAll() *BitSet {
+	clear(b.words)
+	return b
+}
+
+func (b *BitSet) Clone() *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return &BitSet{words: words}
+}
+
+// NextSet returns the smallest set bit greater than or equal to i.
+// If there is none, ok is false.
+func (b *BitSet) NextSet(i uint) (next uint, ok bool) {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		return
+	}
+	word := b.words[w] >> (i % wordSize)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < uint(len(b.words)); w++ {
+		if b.words[w] != 0 {
+			return w*wordSize + uint(bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return
+}
+
+// NextClear returns the smallest clear bit greater than or equal to i.
+// Bits beyond the storage are clear, so there always is one.
+func (b *BitSet) NextClear(i uint) uint {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		return i
+	}
+	word := ^b.words[w] >> (i % wordSize)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word))
+	}
+	for w++; w < uint(len(b.words)); w++ {
+		if b.words[w] != ^uint64(0) {
+			return w*wordSize + uint(bits.TrailingZeros64(^b.words[w]))
+		}
+	}
+	return uint(len(b.words)) * wordSize
+}
+
+// Range calls f for each set bit in ascending order.
+func (b *BitSet) Range(f func(val uint) (Continue bool)) {
+	for w, word := range b.words {
+		for word != 0 {
+			t := bits.TrailingZeros64(word)
+			if !f(uint(w)*wordSize + uint(t)) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+// Slice returns the set bits in ascending order.
+func (b *BitSet) Slice() []uint {
+	s := make([]uint, 0, b.Count())
+	b.Range(func(val uint) bool {
+		s = append(s, val)
+		return true
+	})
+	return s
+}
+
+// ToSet returns the set bits as a set.Set.
+func (b *BitSet) ToSet() set.Set[uint] {
+	s := set.New[uint]()
+	b.Range(func(val uint) bool {
+		s.Insert(val)
+		return true
+	})
+	return s
+}
+
+// Rank returns the number of set bits less than or equal to i.
+func (b *BitSet) Rank(i uint) int {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		return b.Count()
+	}
+	n := 0
+	for _, word := range b.words[:w] {
+		n += bits.OnesCount64(word)
+	}
+	// The shift yields 0 for the last bit of a word, so the mask is all ones.
+	mask := uint64(1)<<(i%wordSize+1) - 1
+	return n + bits.OnesCount64(b.words[w]&mask)
+}
+
+// Select returns the j-th smallest set bit, counting from 0.
+// If j is negative or fewer than j+1 bits are set, ok is false.
+func (b *BitSet) Select(j int) (val uint, ok bool) {
+	if j < 0 {
+		return
+	}
+	for w, word := range b.words {
+		c := bits.OnesCount64(word)
+		if j >= c {
+			j -= c
+			continue
+		}
+		for ; j > 0; j-- {
+			word &= word - 1
+		}
+		return uint(w)*wordSize + uint(bits.TrailingZeros64(word)), true
+	}
+	return
+}
+
+func (b *BitSet) Equal(other *BitSet) bool {
+	short, long := b.words, other.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i, w := range short {
+		if w != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// If b is a subset of other, return true.
+func (b *BitSet) IsSubSet(other *BitSet) bool {
+	for i, w := range b.words {
+		var o uint64
+		if i < len(other.words) {
+			o = other.words[i]
+		}
+		if w&^o != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// And keeps in b only the bits also set in other.
+func (b *BitSet) And(other *BitSet) *BitSet {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+	return b
+}
+
+// Or sets in b every bit set in other.
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	if len(other.words) > 0 {
+		b.grow(uint(len(other.words))*wordSize - 1)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+	return b
+}
+
+// Xor toggles in b every bit set in other.
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	if len(other.words) > 0 {
+		b.grow(uint(len(other.words))*wordSize - 1)
+	}
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+	return b
+}
+
+// AndNot clears in b every bit set in other.
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &^= other.words[i]
+		}
+	}
+	return b
+}
+
+// Contains all elements in b and other.
+func (b *BitSet) Intersection(other *BitSet) *BitSet {
+	return b.Clone().And(other)
+}
+
+// Union all sets.
+func (b *BitSet) Union(other ...*BitSet) *BitSet {
+	union := b.Clone()
+	for _, o := range other {
+		union.Or(o)
+	}
+	return union
+}
+
+// Contains all elements in the original b but not in other.
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	return b.Clone().AndNot(other)
+}
+
+// Contains all elements in b or other but not both.
+func (b *BitSet) SymmetricDifference(other *BitSet) *BitSet {
+	return b.Clone().Xor(other)
+}
@@ -0,0 +1,127 @@
+package bitset
+
+import (
+	"testing"
+
+	"github.com/zijiren233/gencontainer/set"
+	"golang.org/x/exp/slices"
+)
+
+func TestSetClearFlip(t *testing.T) {
+	b := New(10)
+	b.Set(1).Set(64).Set(200)
+	if !b.Test(1) || !b.Test(64) || !b.Test(200) || b.Test(2) || b.Test(1000) {
+		t.Errorf("b.Slice() = %v, want %v", b.Slice(), []uint{1, 64, 200})
+	}
+	if b.Count() != 3 || b.Len() != 3 {
+		t.Errorf("b.Count() = %d, want %d", b.Count(), 3)
+	}
+	b.Clear(64).Clear(5000).Flip(1).Flip(2)
+	if !slices.Equal(b.Slice(), []uint{2, 200}) {
+		t.Errorf("b.Slice() = %v, want %v", b.Slice(), []uint{2, 200})
+	}
+	if !b.Insert(3) || b.Insert(3) || !b.Contain(3) {
+		t.Errorf("b.Insert(3) failed")
+	}
+	if !b.Remove(3) || b.Remove(3) || b.Contain(3) {
+		t.Errorf("b.Remove(3) failed")
+	}
+	if !b.ClearAll().IsEmpty() {
+		t.Errorf("b.ClearAll().IsEmpty() = %t, want %t", false, true)
+	}
+}
+
+func TestNext(t *testing.T) {
+	b := From(0, 1, 2, 63, 64, 130)
+	var got []uint
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		got = append(got, i)
+	}
+	if !slices.Equal(got, b.Slice()) {
+		t.Errorf("NextSet iteration = %v, want %v", got, b.Slice())
+	}
+	if _, ok := b.NextSet(131); ok {
+		t.Errorf("b.NextSet(131) found a bit, want none")
+	}
+	if c := b.NextClear(0); c != 3 {
+		t.Errorf("b.NextClear(0) = %d, want %d", c, 3)
+	}
+	if c := b.NextClear(63); c != 65 {
+		t.Errorf("b.NextClear(63) = %d, want %d", c, 65)
+	}
+	if c := b.NextClear(1000); c != 1000 {
+		t.Errorf("b.NextClear(1000) = %d, want %d", c, 1000)
+	}
+	full := New(0)
+	for i := uint(0); i < 128; i++ {
+		full.Set(i)
+	}
+	if c := full.NextClear(5); c != 128 {
+		t.Errorf("full.NextClear(5) = %d, want %d", c, 128)
+	}
+}
+
+func TestRankSelect(t *testing.T) {
+	b := From(0, 5, 63, 64, 100)
+	for _, tc := range []struct {
+		i    uint
+		rank int
+	}{{0, 1}, {4, 1}, {5, 2}, {62, 2}, {63, 3}, {64, 4}, {99, 4}, {100, 5}, {10000, 5}} {
+		if r := b.Rank(tc.i); r != tc.rank {
+			t.Errorf("b.Rank(%d) = %d, want %d", tc.i, r, tc.rank)
+		}
+	}
+	for j, want := range b.Slice() {
+		if v, ok := b.Select(j); !ok || v != want {
+			t.Errorf("b.Select(%d) = %d, %t, want %d", j, v, ok, want)
+		}
+	}
+	for _, j := range []int{-1, 5, 1000} {
+		if v, ok := b.Select(j); ok {
+			t.Errorf("b.Select(%d) = %d, true, want none", j, v)
+		}
+	}
+	if v, ok := From(70).Select(-1); ok {
+		t.Errorf("From(70).Select(-1) = %d, true, want none", v)
+	}
+}
+
+func TestAlgebra(t *testing.T) {
+	b1 := From(1, 2, 3, 100)
+	b2 := From(2, 3, 4)
+	if s := b1.Intersection(b2).Slice(); !slices.Equal(s, []uint{2, 3}) {
+		t.Errorf("Intersection = %v", s)
+	}
+	if s := b1.Union(b2).Slice(); !slices.Equal(s, []uint{1, 2, 3, 4, 100}) {
+		t.Errorf("Union = %v", s)
+	}
+	if s := b1.Difference(b2).Slice(); !slices.Equal(s, []uint{1, 100}) {
+		t.Errorf("Difference = %v", s)
+	}
+	if s := b2.Difference(b1).Slice(); !slices.Equal(s, []uint{4}) {
+		t.Errorf("Difference = %v", s)
+	}
+	if s := b1.SymmetricDifference(b2).Slice(); !slices.Equal(s, []uint{1, 4, 100}) {
+		t.Errorf("SymmetricDifference = %v", s)
+	}
+	if s := b2.Clone().And(b1).Slice(); !slices.Equal(s, []uint{2, 3}) {
+		t.Errorf("And = %v", s)
+	}
+	if !From(2, 3).IsSubSet(b1) || b2.IsSubSet(b1) {
+		t.Errorf("IsSubSet failed")
+	}
+	if !From(1, 200).Clear(200).Equal(From(1)) {
+		t.Errorf("Equal failed with trailing zero words")
+	}
+}
+
+func TestSetConversion(t *testing.T) {
+	s := set.New[uint]().Push(1, 70, 3)
+	b := FromSet(s)
+	if !slices.Equal(b.Slice(), []uint{1, 3, 70}) {
+		t.Errorf("FromSet = %v", b.Slice())
+	}
+	if !b.ToSet().Equal(s) {
+		t.Errorf("ToSet = %v, want %v", b.ToSet().Slice(), s.Slice())
+	}
+}
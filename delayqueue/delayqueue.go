@@ -0,0 +1,276 @@
+// delay queue
+package delayqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zijiren233/gencontainer/heap"
+)
+
+// Clock is the source of time used by a Queue.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine after d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending AfterFunc call.
+type Timer interface {
+	// Stop prevents the call from running. It reports whether the call was stopped
+	// before it ran.
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// Item is a value waiting in a Queue. The *Item returned by Put is a handle
+// that can be passed to Cancel and Reschedule while the item is queued.
+type Item[T any] struct {
+	value T
+	// at is guarded by q.mu, as Reschedule may change it.
+	at time.Time
+	q  *Queue[T]
+
+	// index is the position of the item in the heap, or -1 once taken or canceled.
+	index int
+}
+
+// Value returns the value of the item.
+func (i *Item[T]) Value() T {
+	return i.value
+}
+
+// At returns the time at which the item becomes available.
+func (i *Item[T]) At() time.Time {
+	i.q.mu.Lock()
+	defer i.q.mu.Unlock()
+	return i.at
+}
+
+var _ heap.Interface[*Item[int]] = (*items[int])(nil)
+
+type items[T any] []*Item[T]
+
+func (h items[T]) Len() int {
+	return len(h)
+}
+
+func (h items[T]) Less(i, j int) bool {
+	return h[i].at.Before(h[j].at)
+}
+
+func (h items[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *items[T]) Push(i *Item[T]) {
+	i.index = len(*h)
+	*h = append(*h, i)
+}
+
+func (h *items[T]) Pop() *Item[T] {
+	n := len(*h)
+	i := (*h)[n-1]
+	(*h)[n-1] = nil
+	i.index = -1
+	*h = (*h)[:n-1]
+	return i
+}
+
+// Queue holds values until a given time. Take returns them in the order
+// they become due. A Queue is safe for concurrent use by multiple goroutines.
+//
+// However many items are queued, a Queue uses a single timer, armed for the
+// earliest item.
+type Queue[T any] struct {
+	mu    sync.Mutex
+	items items[T]
+	clock Clock
+
+	// timer is armed for timerAt, the due time of the earliest item.
+	// timerGen identifies the armed timer so that a stale call can be ignored.
+	timer    Timer
+	timerAt  time.Time
+	timerGen uint64
+
+	// changed is closed and replaced whenever the earliest item may have
+	// changed or become due, waking every goroutine blocked in Take.
+	changed chan struct{}
+}
+
+type QueueConf[T any] func(q *Queue[T])
+
+// WithClock makes the queue read time from c instead of the system clock.
+func WithClock[T any](c Clock) QueueConf[T] {
+	return func(q *Queue[T]) {
+		q.clock = c
+	}
+}
+
+func New[T any](conf ...QueueConf[T]) *Queue[T] {
+	q := &Queue[T]{
+		clock:   realClock{},
+		changed: make(chan struct{}),
+	}
+	for _, c := range conf {
+		c(q)
+	}
+	return q
+}
+
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// Put queues value until at and returns its handle.
+func (q *Queue[T]) Put(value T, at time.Time) *Item[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item := &Item[T]{value: value, at: at, q: q, index: -1}
+	heap.Push[*Item[T]](&q.items, item)
+	if item.index == 0 {
+		q.headChangedLocked()
+	}
+	return item
+}
+
+// Cancel removes a queued item.
+// If the item is nil or not in q, Cancel returns false.
+func (q *Queue[T]) Cancel(item *Item[T]) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.containsLocked(item) {
+		return false
+	}
+	wasHead := item.index == 0
+	heap.Remove[*Item[T]](&q.items, item.index)
+	if wasHead {
+		q.headChangedLocked()
+	}
+	return true
+}
+
+// Reschedule changes the time at which a queued item becomes available.
+// If the item is nil or not in q, Reschedule returns false.
+func (q *Queue[T]) Reschedule(item *Item[T], at time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.containsLocked(item) {
+		return false
+	}
+	wasHead := item.index == 0
+	item.at = at
+	heap.Fix[*Item[T]](&q.items, item.index)
+	if wasHead || item.index == 0 {
+		q.headChangedLocked()
+	}
+	return true
+}
+
+// TryTake removes and returns the earliest item if it is due.
+func (q *Queue[T]) TryTake() (value T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.items.Len() == 0 || q.items[0].at.After(q.clock.Now()) {
+		return
+	}
+	return q.popLocked(), true
+}
+
+// Take removes and returns the earliest item, blocking until it is due.
+// If ctx is done first, Take returns ctx.Err().
+func (q *Queue[T]) Take(ctx context.Context) (value T, err error) {
+	q.mu.Lock()
+	for q.items.Len() == 0 || q.items[0].at.After(q.clock.Now()) {
+		changed := q.changed
+		q.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		}
+		q.mu.Lock()
+	}
+	defer q.mu.Unlock()
+	return q.popLocked(), nil
+}
+
+// containsLocked reports whether item is queued in q. A nil item or one
+// from another queue is never queued; the latter's index is guarded by
+// another mutex and must not be read.
+func (q *Queue[T]) containsLocked(item *Item[T]) bool {
+	return item != nil && item.q == q && item.index >= 0 && item.index < q.items.Len() && q.items[item.index] == item
+}
+
+func (q *Queue[T]) popLocked() T {
+	item := heap.Pop[*Item[T]](&q.items)
+	q.headChangedLocked()
+	return item.value
+}
+
+// headChangedLocked wakes waiting Take calls and re-arms the timer for the
+// new earliest item.
+func (q *Queue[T]) headChangedLocked() {
+	q.broadcastLocked()
+	if q.items.Len() == 0 {
+		q.stopTimerLocked()
+		return
+	}
+	if q.timer != nil && q.timerAt.Equal(q.items[0].at) {
+		return
+	}
+	q.stopTimerLocked()
+	q.armLocked()
+}
+
+func (q *Queue[T]) broadcastLocked() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// armLocked starts the timer for the earliest item. The timer must be stopped.
+func (q *Queue[T]) armLocked() {
+	q.timerGen++
+	gen := q.timerGen
+	q.timerAt = q.items[0].at
+	q.timer = q.clock.AfterFunc(q.timerAt.Sub(q.clock.Now()), func() {
+		q.fire(gen)
+	})
+}
+
+func (q *Queue[T]) stopTimerLocked() {
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+}
+
+func (q *Queue[T]) fire(gen uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if gen != q.timerGen {
+		// A timer that was stopped too late to prevent this call.
+		return
+	}
+	q.timer = nil
+	q.broadcastLocked()
+	// Re-arm if the timer fired before the earliest item is due by the clock.
+	if q.items.Len() > 0 && q.items[0].at.After(q.clock.Now()) {
+		q.armLocked()
+	}
+}
@@ -0,0 +1,234 @@
+package delayqueue_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zijiren233/gencontainer/delayqueue"
+)
+
+type fakeTimer struct {
+	c       *fakeClock
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	return true
+}
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+	// running counts fired timer funcs that have not returned yet.
+	running sync.WaitGroup
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) delayqueue.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{c: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	c.fireLocked()
+	return t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.fireLocked()
+}
+
+// pending returns the number of timers that are armed and not yet fired.
+func (c *fakeClock) pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+func (c *fakeClock) fireLocked() {
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		switch {
+		case t.stopped:
+		case !t.at.After(c.now):
+			t.stopped = true
+			c.running.Add(1)
+			go func() {
+				defer c.running.Done()
+				t.f()
+			}()
+		default:
+			timers = append(timers, t)
+		}
+	}
+	c.timers = timers
+}
+
+// settle waits until every fired timer func has returned.
+func (c *fakeClock) settle() {
+	c.running.Wait()
+}
+
+func takeAsync(q *delayqueue.Queue[string]) <-chan string {
+	ch := make(chan string, 1)
+	go func() {
+		v, _ := q.Take(context.Background())
+		ch <- v
+	}()
+	return ch
+}
+
+// expectBlocked checks that the Take behind ch is still waiting on an armed
+// timer once the fired timers have run. A Take that wrongly returns later
+// is caught by the value received from ch afterwards.
+func expectBlocked(t *testing.T, clock *fakeClock, ch <-chan string) {
+	t.Helper()
+	clock.settle()
+	if clock.pending() != 1 {
+		t.Fatalf("queue armed %d timers; want 1", clock.pending())
+	}
+	select {
+	case v := <-ch:
+		t.Fatalf("Take returned %s before it was due", v)
+	default:
+	}
+}
+
+func TestTake(t *testing.T) {
+	clock := newFakeClock()
+	q := delayqueue.New(delayqueue.WithClock[string](clock))
+	start := clock.Now()
+	q.Put("b", start.Add(2*time.Second))
+	q.Put("a", start.Add(time.Second))
+	q.Put("c", start.Add(3*time.Second))
+	if clock.pending() != 1 {
+		t.Fatalf("queue armed %d timers; want 1", clock.pending())
+	}
+
+	ch := takeAsync(q)
+	expectBlocked(t, clock, ch)
+	clock.Advance(time.Second)
+	if v := <-ch; v != "a" {
+		t.Fatalf("Take got %s; want %s", v, "a")
+	}
+
+	if _, ok := q.TryTake(); ok {
+		t.Fatalf("TryTake returned an item before it was due")
+	}
+	clock.Advance(5 * time.Second)
+	for _, want := range []string{"b", "c"} {
+		if v, ok := q.TryTake(); !ok || v != want {
+			t.Fatalf("TryTake got %s, %t; want %s", v, ok, want)
+		}
+	}
+}
+
+func TestCancelReschedule(t *testing.T) {
+	clock := newFakeClock()
+	q := delayqueue.New(delayqueue.WithClock[string](clock))
+	start := clock.Now()
+	a := q.Put("a", start.Add(time.Second))
+	b := q.Put("b", start.Add(2*time.Second))
+	c := q.Put("c", start.Add(3*time.Second))
+
+	if !q.Cancel(a) || q.Cancel(a) {
+		t.Fatalf("Cancel failed")
+	}
+	if q.Cancel(nil) || q.Reschedule(nil, start) {
+		t.Fatalf("Cancel or Reschedule of a nil item succeeded")
+	}
+	other := delayqueue.New(delayqueue.WithClock[string](clock))
+	foreign := other.Put("x", start.Add(time.Second))
+	if q.Cancel(foreign) || q.Reschedule(foreign, start) {
+		t.Fatalf("Cancel or Reschedule of another queue's item succeeded")
+	}
+	if other.Len() != 1 {
+		t.Fatalf("other.Len() = %d; want 1", other.Len())
+	}
+	if !q.Reschedule(c, start.Add(time.Second/2)) {
+		t.Fatalf("Reschedule failed")
+	}
+
+	ch := takeAsync(q)
+	clock.Advance(time.Second / 2)
+	if v := <-ch; v != "c" {
+		t.Fatalf("Take got %s; want %s", v, "c")
+	}
+	if q.Reschedule(c, start) {
+		t.Fatalf("Reschedule on a taken item succeeded")
+	}
+
+	// Postponing the earliest item must re-arm the timer for its new time.
+	q.Reschedule(b, start.Add(5*time.Second))
+	ch = takeAsync(q)
+	clock.Advance(2 * time.Second)
+	expectBlocked(t, clock, ch)
+	clock.Advance(3 * time.Second)
+	if v := <-ch; v != "b" {
+		t.Fatalf("Take got %s; want %s", v, "b")
+	}
+	if q.Len() != 0 {
+		t.Fatalf("Len() = %d; want 0", q.Len())
+	}
+}
+
+func TestTakeContext(t *testing.T) {
+	clock := newFakeClock()
+	q := delayqueue.New(delayqueue.WithClock[string](clock))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	q.Put("a", clock.Now().Add(time.Hour))
+	if _, err := q.Take(ctx); err != context.Canceled {
+		t.Fatalf("Take got %v; want %v", err, context.Canceled)
+	}
+
+	q.Put("b", clock.Now().Add(5*time.Millisecond))
+	ch := takeAsync(q)
+	clock.Advance(5 * time.Millisecond)
+	if v := <-ch; v != "b" {
+		t.Fatalf("Take got %s; want %s", v, "b")
+	}
+}
+
+func TestAtReschedule(t *testing.T) {
+	clock := newFakeClock()
+	q := delayqueue.New(delayqueue.WithClock[int](clock))
+	start := clock.Now()
+	item := q.Put(1, start)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			item.At()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		q.Reschedule(item, start.Add(time.Duration(i)*time.Second))
+	}
+	<-done
+	if at := item.At(); !at.Equal(start.Add(99 * time.Second)) {
+		t.Fatalf("At() = %v; want %v", at, start.Add(99*time.Second))
+	}
+}
@@ -0,0 +1,310 @@
+// double-ended queue
+package deque
+
+// chunkSize is the number of elements per chunk.
+const chunkSize = 64
+
+type chunk[T any] [chunkSize]T
+
+// Deque is a double-ended queue backed by a growable ring of fixed-size
+// chunks. Pushing and popping at either end take O(1) amortized time and
+// allocate one chunk per chunkSize elements, and At and Set take O(1) time.
+//
+// Chunks freed by pops and Clear are kept for reuse unless the deque is
+// configured WithShrink, or Shrink is called.
+//
+// The zero Deque is empty and ready for use.
+type Deque[T any] struct {
+	// chunks is a ring of chunk slots. The chunks in use are the nchunks
+	// slots starting at first; other slots may hold spare chunks or nil.
+	chunks  []*chunk[T]
+	first   int
+	nchunks int
+
+	// off is the position of the front element in the first chunk.
+	off int
+	len int
+
+	shrink bool
+}
+
+type DequeConf[T any] func(d *Deque[T])
+
+// WithShrink makes the deque release spare chunks as it empties,
+// instead of keeping them for reuse.
+func WithShrink[T any]() DequeConf[T] {
+	return func(d *Deque[T]) {
+		d.shrink = true
+	}
+}
+
+func New[T any](conf ...DequeConf[T]) *Deque[T] {
+	d := &Deque[T]{}
+	for _, c := range conf {
+		c(d)
+	}
+	return d
+}
+
+func (d *Deque[T]) Len() int {
+	return d.len
+}
+
+func (d *Deque[T]) IsEmpty() bool {
+	return d.len == 0
+}
+
+// slot returns the chunk holding the element at logical position p,
+// counted from the start of the first chunk, and its index in the chunk.
+func (d *Deque[T]) slot(p int) (*chunk[T], int) {
+	c := d.first + p/chunkSize
+	if c >= len(d.chunks) {
+		c -= len(d.chunks)
+	}
+	return d.chunks[c], p % chunkSize
+}
+
+func (d *Deque[T]) at(i int) *T {
+	c, j := d.slot(d.off + i)
+	return &c[j]
+}
+
+// growRing doubles the number of chunk slots, keeping the chunks in order.
+func (d *Deque[T]) growRing() {
+	n := len(d.chunks)
+	chunks := make([]*chunk[T], max(4, 2*n))
+	for i := 0; i < n; i++ {
+		chunks[i] = d.chunks[(d.first+i)%n]
+	}
+	d.chunks = chunks
+	d.first = 0
+}
+
+func (d *Deque[T]) PushBack(v T) {
+	if d.off+d.len == d.nchunks*chunkSize {
+		if d.nchunks == len(d.chunks) {
+			d.growRing()
+		}
+		c := (d.first + d.nchunks) % len(d.chunks)
+		if d.chunks[c] == nil {
+			d.chunks[c] = new(chunk[T])
+		}
+		d.nchunks++
+	}
+	*d.at(d.len) = v
+	d.len++
+}
+
+func (d *Deque[T]) PushFront(v T) {
+	if d.off == 0 {
+		if d.nchunks == len(d.chunks) {
+			d.growRing()
+		}
+		d.first--
+		if d.first < 0 {
+			d.first += len(d.chunks)
+		}
+		if d.chunks[d.first] == nil {
+			d.chunks[d.first] = new(chunk[T])
+		}
+		d.nchunks++
+		d.off = chunkSize
+	}
+	d.off--
+	d.len++
+	*d.at(0) = v
+}
+
+// PopFront removes and returns the front element.
+// If the deque is empty, ok is false.
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	if d.len == 0 {
+		return
+	}
+	var zero T
+	p := d.at(0)
+	v, *p = *p, zero
+	d.off++
+	d.len--
+	if d.off == chunkSize {
+		d.first = (d.first + 1) % len(d.chunks)
+		d.nchunks--
+		d.off = 0
+	}
+	d.trim()
+	return v, true
+}
+
+// PopBack removes and returns the back element.
+// If the deque is empty, ok is false.
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	if d.len == 0 {
+		return
+	}
+	var zero T
+	p := d.at(d.len - 1)
+	v, *p = *p, zero
+	d.len--
+	if d.nchunks*chunkSize-(d.off+d.len) == chunkSize {
+		d.nchunks--
+	}
+	d.trim()
+	return v, true
+}
+
+// trim resets an empty deque and releases spare chunks in shrink mode.
+func (d *Deque[T]) trim() {
+	if d.len == 0 {
+		d.off, d.nchunks = 0, 0
+	}
+	if d.shrink && len(d.chunks) > 4 && d.nchunks <= len(d.chunks)/4 {
+		d.Shrink()
+	}
+}
+
+// Shrink releases the spare chunks and the unused chunk slots.
+func (d *Deque[T]) Shrink() {
+	if d.nchunks == 0 {
+		d.chunks, d.first = nil, 0
+		return
+	}
+	n := len(d.chunks)
+	chunks := make([]*chunk[T], max(4, d.nchunks))
+	for i := 0; i < d.nchunks; i++ {
+		chunks[i] = d.chunks[(d.first+i)%n]
+	}
+	d.chunks = chunks
+	d.first = 0
+}
+
+// Front returns the front element without removing it.
+// If the deque is empty, ok is false.
+func (d *Deque[T]) Front() (v T, ok bool) {
+	if d.len == 0 {
+		return
+	}
+	return *d.at(0), true
+}
+
+// Back returns the back element without removing it.
+// If the deque is empty, ok is false.
+func (d *Deque[T]) Back() (v T, ok bool) {
+	if d.len == 0 {
+		return
+	}
+	return *d.at(d.len - 1), true
+}
+
+func (d *Deque[T]) checkIndex(i int) {
+	if i < 0 || i >= d.len {
+		panic("deque: index out of range")
+	}
+}
+
+// At returns the i-th element from the front.
+// It panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	d.checkIndex(i)
+	return *d.at(i)
+}
+
+// Set replaces the i-th element from the front.
+// It panics if i is out of range.
+func (d *Deque[T]) Set(i int, v T) {
+	d.checkIndex(i)
+	*d.at(i) = v
+}
+
+// Insert inserts v so that it becomes the i-th element, shifting the
+// elements on the shorter side. i may be Len() to insert at the back.
+// It panics if i is out of range.
+func (d *Deque[T]) Insert(i int, v T) {
+	if i < 0 || i > d.len {
+		panic("deque: index out of range")
+	}
+	if i < d.len/2 {
+		var zero T
+		d.PushFront(zero)
+		for j := 0; j < i; j++ {
+			*d.at(j) = *d.at(j + 1)
+		}
+	} else {
+		var zero T
+		d.PushBack(zero)
+		for j := d.len - 1; j > i; j-- {
+			*d.at(j) = *d.at(j - 1)
+		}
+	}
+	*d.at(i) = v
+}
+
+// Remove removes and returns the i-th element, shifting the elements on
+// the shorter side. It panics if i is out of range.
+func (d *Deque[T]) Remove(i int) T {
+	d.checkIndex(i)
+	v := *d.at(i)
+	if i < d.len/2 {
+		for j := i; j > 0; j-- {
+			*d.at(j) = *d.at(j - 1)
+		}
+		d.PopFront()
+	} else {
+		for j := i; j < d.len-1; j++ {
+			*d.at(j) = *d.at(j + 1)
+		}
+		d.PopBack()
+	}
+	return v
+}
+
+// Rotate moves n elements from the front to the back, or -n elements from
+// the back to the front if n is negative.
+func (d *Deque[T]) Rotate(n int) {
+	if d.len <= 1 {
+		return
+	}
+	n %= d.len
+	if n < 0 {
+		n += d.len
+	}
+	if n <= d.len/2 {
+		for ; n > 0; n-- {
+			v, _ := d.PopFront()
+			d.PushBack(v)
+		}
+		return
+	}
+	for n = d.len - n; n > 0; n-- {
+		v, _ := d.PopBack()
+		d.PushFront(v)
+	}
+}
+
+// Clear removes all elements. The chunks are kept for reuse unless the
+// deque is configured WithShrink.
+func (d *Deque[T]) Clear() {
+	for i := 0; i < d.nchunks; i++ {
+		clear(d.chunks[(d.first+i)%len(d.chunks)][:])
+	}
+	d.len = 0
+	d.trim()
+}
+
+// Slice returns the elements from front to back.
+func (d *Deque[T]) Slice() []T {
+	s := make([]T, d.len)
+	for i := range s {
+		s[i] = *d.at(i)
+	}
+	return s
+}
+
+// Range calls f for each element from front to back with its position.
+// If f returns false, range stops the iteration.
+func (d *Deque[T]) Range(f func(i int, v T) bool) {
+	for i := 0; i < d.len; i++ {
+		if !f(i, *d.at(i)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package deque
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/zijiren233/gencontainer/dllist"
+	"golang.org/x/exp/slices"
+)
+
+// checkModel compares d with a slice holding the same elements.
+func checkModel(t *testing.T, d *Deque[int], model []int) {
+	t.Helper()
+	if d.Len() != len(model) {
+		t.Fatalf("d.Len() = %d, want %d", d.Len(), len(model))
+	}
+	if s := d.Slice(); !slices.Equal(s, model) {
+		t.Fatalf("d.Slice() = %v, want %v", s, model)
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	d := New[int]()
+	if _, ok := d.PopFront(); ok {
+		t.Errorf("PopFront on an empty deque succeeded")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Errorf("PopBack on an empty deque succeeded")
+	}
+	var model []int
+	for i := 0; i < 1000; i++ {
+		if i%3 == 0 {
+			d.PushFront(i)
+			model = append([]int{i}, model...)
+		} else {
+			d.PushBack(i)
+			model = append(model, i)
+		}
+	}
+	checkModel(t, d, model)
+	for d.Len() > 0 {
+		if d.Len()%2 == 0 {
+			v, _ := d.PopFront()
+			if v != model[0] {
+				t.Fatalf("PopFront() = %d, want %d", v, model[0])
+			}
+			model = model[1:]
+		} else {
+			v, _ := d.PopBack()
+			if v != model[len(model)-1] {
+				t.Fatalf("PopBack() = %d, want %d", v, model[len(model)-1])
+			}
+			model = model[:len(model)-1]
+		}
+	}
+	checkModel(t, d, model)
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, d := range []*Deque[int]{New[int](), New(WithShrink[int]())} {
+		var model []int
+		for i := 0; i < 20000; i++ {
+			switch op := r.Intn(10); {
+			case op < 3:
+				d.PushBack(i)
+				model = append(model, i)
+			case op < 6:
+				d.PushFront(i)
+				model = append([]int{i}, model...)
+			case op < 7 && len(model) > 0:
+				d.PopFront()
+				model = model[1:]
+			case op < 8 && len(model) > 0:
+				d.PopBack()
+				model = model[:len(model)-1]
+			case op < 9:
+				j := r.Intn(len(model) + 1)
+				d.Insert(j, i)
+				model = slices.Insert(model, j, i)
+			case len(model) > 0:
+				j := r.Intn(len(model))
+				if v := d.Remove(j); v != model[j] {
+					t.Fatalf("Remove(%d) = %d, want %d", j, v, model[j])
+				}
+				model = slices.Delete(model, j, j+1)
+			}
+		}
+		checkModel(t, d, model)
+		for i := range model {
+			if d.At(i) != model[i] {
+				t.Fatalf("At(%d) = %d, want %d", i, d.At(i), model[i])
+			}
+		}
+	}
+}
+
+func TestRotate(t *testing.T) {
+	d := New[int]()
+	for i := 0; i < 10; i++ {
+		d.PushBack(i)
+	}
+	d.Rotate(3)
+	checkModel(t, d, []int{3, 4, 5, 6, 7, 8, 9, 0, 1, 2})
+	d.Rotate(-3)
+	checkModel(t, d, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	d.Rotate(18)
+	checkModel(t, d, []int{8, 9, 0, 1, 2, 3, 4, 5, 6, 7})
+}
+
+func TestClear(t *testing.T) {
+	d := New[int]()
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+	}
+	chunks := len(d.chunks)
+	d.Clear()
+	checkModel(t, d, nil)
+	if len(d.chunks) != chunks {
+		t.Errorf("Clear released the chunks")
+	}
+	d.PushFront(1)
+	d.Set(0, 2)
+	if v, _ := d.Front(); v != 2 {
+		t.Errorf("Front() = %d, want %d", v, 2)
+	}
+
+	s := New(WithShrink[int]())
+	for i := 0; i < 1000; i++ {
+		s.PushBack(i)
+	}
+	s.Clear()
+	if s.chunks != nil {
+		t.Errorf("Clear in shrink mode kept %d chunk slots", len(s.chunks))
+	}
+}
+
+const benchN = 1000
+
+func BenchmarkDequePushPop(b *testing.B) {
+	d := New[int]()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < benchN; j++ {
+			d.PushBack(j)
+			d.PushFront(j)
+		}
+		for d.Len() > 0 {
+			d.PopFront()
+			d.PopBack()
+		}
+	}
+}
+
+func BenchmarkDllistPushPop(b *testing.B) {
+	l := dllist.New[int]()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < benchN; j++ {
+			l.PushBack(j)
+			l.PushFront(j)
+		}
+		for l.Len() > 0 {
+			l.Remove(l.Front())
+			l.Remove(l.Back())
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package heap
+
+// The functions in this file are the d-ary counterparts of Init, Push, Pop,
+// Remove and Fix: every node has up to d children instead of two. A larger
+// arity makes the heap shallower, so Push and Fix do fewer swaps and Pop
+// touches fewer cache lines, at the cost of more comparisons per level.
+// A heap must always be used with the same arity. An arity of 2 is the same
+// as the binary functions.
+
+func checkArity(d int) {
+	if d < 2 {
+		panic("heap: arity must be at least 2")
+	}
+}
+
+func InitD[T any](h Interface[T], d int) {
+	checkArity(d)
+	n := h.Len()
+	for i := (n - 2) / d; i >= 0; i-- {
+		downD(h, d, i, n)
+	}
+}
+
+func PushD[T any](h Interface[T], d int, x T) {
+	checkArity(d)
+	h.Push(x)
+	upD(h, d, h.Len()-1)
+}
+
+func PopD[T any](h Interface[T], d int) T {
+	checkArity(d)
+	n := h.Len() - 1
+	h.Swap(0, n)
+	downD(h, d, 0, n)
+	return h.Pop()
+}
+
+func RemoveD[T any](h Interface[T], d int, i int) T {
+	checkArity(d)
+	n := h.Len() - 1
+	if n != i {
+		h.Swap(i, n)
+		if !downD(h, d, i, n) {
+			upD(h, d, i)
+		}
+	}
+	return h.Pop()
+}
+
+func FixD[T any](h Interface[T], d int, i int) {
+	checkArity(d)
+	if !downD(h, d, i, h.Len()) {
+		upD(h, d, i)
+	}
+}
+
+func upD[T any](h Interface[T], d, j int) {
+	for j > 0 {
+		i := (j - 1) / d
+		if !h.Less(j, i) {
+			break
+		}
+		h.Swap(i, j)
+		j = i
+	}
+}
+
+func downD[T any](h Interface[T], d, i0, n int) bool {
+	i := i0
+	for {
+		j1 := d*i + 1
+		if j1 >= n || j1 < 0 {
+			break
+		}
+		j := j1
+		for k := j1 + 1; k < j1+d && k < n; k++ {
+			if h.Less(k, j) {
+				j = k
+			}
+		}
+		if !h.Less(j, i) {
+			break
+		}
+		h.Swap(i, j)
+		i = j
+	}
+	return i > i0
+}
@@ -0,0 +1,179 @@
+package heap
+
+var _ Interface[int] = (*lessSlice[int])(nil)
+
+// lessSlice implements Interface over a slice ordered by a less function.
+type lessSlice[T any] struct {
+	d    []T
+	less func(a, b T) bool
+}
+
+func (h *lessSlice[T]) Len() int {
+	return len(h.d)
+}
+
+func (h *lessSlice[T]) Less(i, j int) bool {
+	return h.less(h.d[i], h.d[j])
+}
+
+func (h *lessSlice[T]) Swap(i, j int) {
+	h.d[i], h.d[j] = h.d[j], h.d[i]
+}
+
+func (h *lessSlice[T]) Push(x T) {
+	h.d = append(h.d, x)
+}
+
+func (h *lessSlice[T]) Pop() T {
+	n := len(h.d)
+	x := h.d[n-1]
+	var zero T
+	h.d[n-1] = zero
+	h.d = h.d[:n-1]
+	return x
+}
+
+// Heap is a heap ordered by a less function: the element for which
+// less reports true against every other element is at the top.
+// Unlike MinHeap and MaxHeap it works with any element type and keeps the
+// heap invariant itself, so its methods can be called directly.
+// It is binary unless configured WithArity.
+type Heap[T any] struct {
+	h     lessSlice[T]
+	arity int
+}
+
+type HeapConf[T any] func(h *Heap[T])
+
+// WithArity makes the heap d-ary: every node has up to d children.
+// A 4-ary heap is often faster than a binary one for large heaps.
+func WithArity[T any](d int) HeapConf[T] {
+	checkArity(d)
+	return func(h *Heap[T]) {
+		h.arity = d
+	}
+}
+
+// New returns an empty heap ordered by less.
+func New[T any](less func(a, b T) bool, conf ...HeapConf[T]) *Heap[T] {
+	h := &Heap[T]{h: lessSlice[T]{less: less}, arity: 2}
+	for _, c := range conf {
+		c(h)
+	}
+	return h
+}
+
+// NewFrom returns a heap ordered by less holding the elements of data.
+// The heap takes ownership of data and establishes the heap invariant in O(n).
+func NewFrom[T any](less func(a, b T) bool, data []T, conf ...HeapConf[T]) *Heap[T] {
+	h := New(less, conf...)
+	h.Init(data)
+	return h
+}
+
+func (h *Heap[T]) init() {
+	if h.arity == 2 {
+		Init[T](&h.h)
+	} else {
+		InitD[T](&h.h, h.arity)
+	}
+}
+
+func (h *Heap[T]) push(x T) {
+	if h.arity == 2 {
+		Push[T](&h.h, x)
+	} else {
+		PushD[T](&h.h, h.arity, x)
+	}
+}
+
+func (h *Heap[T]) pop() T {
+	if h.arity == 2 {
+		return Pop[T](&h.h)
+	}
+	return PopD[T](&h.h, h.arity)
+}
+
+func (h *Heap[T]) fixTop() {
+	if h.arity == 2 {
+		down[T](&h.h, 0, h.h.Len())
+	} else {
+		downD[T](&h.h, h.arity, 0, h.h.Len())
+	}
+}
+
+func (h *Heap[T]) Len() int {
+	return h.h.Len()
+}
+
+// Init replaces the contents of the heap with data in O(n).
+// The heap takes ownership of data.
+func (h *Heap[T]) Init(data []T) {
+	h.h.d = data
+	h.init()
+}
+
+// Push pushes x onto the heap.
+func (h *Heap[T]) Push(x ...T) {
+	for _, v := range x {
+		h.push(v)
+	}
+}
+
+// Pop removes and returns the top element.
+// If the heap is empty, ok is false.
+func (h *Heap[T]) Pop() (x T, ok bool) {
+	if h.h.Len() == 0 {
+		return
+	}
+	return h.pop(), true
+}
+
+// Peek returns the top element without removing it.
+// If the heap is empty, ok is false.
+func (h *Heap[T]) Peek() (x T, ok bool) {
+	if h.h.Len() == 0 {
+		return
+	}
+	return h.h.d[0], true
+}
+
+// PushPop pushes x and then pops the top element, more efficiently than
+// calling Push followed by Pop. If x would be the new top it is returned
+// immediately and the heap is unchanged.
+func (h *Heap[T]) PushPop(x T) T {
+	if h.h.Len() == 0 || !h.h.less(h.h.d[0], x) {
+		return x
+	}
+	x, h.h.d[0] = h.h.d[0], x
+	h.fixTop()
+	return x
+}
+
+// Replace pops the top element and then pushes x, more efficiently than
+// calling Pop followed by Push. The returned element may be ordered after x.
+// If the heap is empty, x is pushed and ok is false.
+func (h *Heap[T]) Replace(x T) (top T, ok bool) {
+	if h.h.Len() == 0 {
+		h.h.Push(x)
+		return
+	}
+	top, h.h.d[0] = h.h.d[0], x
+	h.fixTop()
+	return top, true
+}
+
+// Drain pops every element and returns them in order, leaving the heap empty.
+func (h *Heap[T]) Drain() []T {
+	d := make([]T, h.h.Len())
+	for i := range d {
+		d[i] = h.pop()
+	}
+	return d
+}
+
+// Clear removes all elements, keeping the allocated storage.
+func (h *Heap[T]) Clear() {
+	clear(h.h.d)
+	h.h.d = h.h.d[:0]
+}
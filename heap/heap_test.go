@@ -3,6 +3,8 @@ package heap
 import (
 	"math/rand"
 	"testing"
+
+	"golang.org/x/exp/slices"
 )
 
 type myHeap = MinHeap[int]
@@ -187,3 +189,311 @@ func TestFix(t *testing.T) {
 		verify(t, *h, 0)
 	}
 }
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestGenericHeap(t *testing.T) {
+	h := New(func(a, b person) bool { return a.age < b.age })
+	if _, ok := h.Pop(); ok {
+		t.Fatalf("Pop on an empty heap succeeded")
+	}
+	for i := 20; i > 0; i-- {
+		h.Push(person{age: i})
+	}
+	if top, ok := h.Peek(); !ok || top.age != 1 {
+		t.Fatalf("Peek got %v; want %d", top.age, 1)
+	}
+	for i := 1; h.Len() > 0; i++ {
+		x, _ := h.Pop()
+		if x.age != i {
+			t.Errorf("%d.th pop got %d; want %d", i, x.age, i)
+		}
+	}
+}
+
+func TestNewFrom(t *testing.T) {
+	data := rand.Perm(100)
+	h := NewFrom(func(a, b int) bool { return a < b }, data)
+	verify(t, myHeap(h.h.d), 0)
+	d := h.Drain()
+	for i, x := range d {
+		if x != i {
+			t.Fatalf("Drain()[%d] = %d; want %d", i, x, i)
+		}
+	}
+	if h.Len() != 0 {
+		t.Fatalf("Len after Drain = %d; want 0", h.Len())
+	}
+}
+
+func TestPushPopReplace(t *testing.T) {
+	h := NewFrom(func(a, b int) bool { return a < b }, []int{5, 3, 8})
+	if x := h.PushPop(1); x != 1 {
+		t.Errorf("PushPop(1) = %d; want %d", x, 1)
+	}
+	if x := h.PushPop(4); x != 3 {
+		t.Errorf("PushPop(4) = %d; want %d", x, 3)
+	}
+	if x, ok := h.Replace(2); !ok || x != 4 {
+		t.Errorf("Replace(2) = %d, %t; want %d, %t", x, ok, 4, true)
+	}
+	want := []int{2, 5, 8}
+	for i, x := range h.Drain() {
+		if x != want[i] {
+			t.Errorf("Drain()[%d] = %d; want %d", i, x, want[i])
+		}
+	}
+	if _, ok := h.Replace(7); ok {
+		t.Errorf("Replace on an empty heap returned a value")
+	}
+	if x, _ := h.Peek(); x != 7 {
+		t.Errorf("Peek after Replace = %d; want %d", x, 7)
+	}
+}
+
+func verifyD(t *testing.T, h myHeap, d int) {
+	t.Helper()
+	for i := 1; i < h.Len(); i++ {
+		if p := (i - 1) / d; h.Less(i, p) {
+			t.Fatalf("%d-ary heap invariant invalidated [%d] = %d > [%d] = %d", d, p, h[p], i, h[i])
+		}
+	}
+}
+
+func TestDary(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		h := myHeap(rand.Perm(200))
+		InitD[int](&h, d)
+		verifyD(t, h, d)
+		for i := 200; i < 250; i++ {
+			PushD[int](&h, d, i-225)
+			verifyD(t, h, d)
+		}
+		for i := 0; i < 20; i++ {
+			elem := rand.Intn(h.Len())
+			h[elem] = rand.Intn(300) - 50
+			FixD[int](&h, d, elem)
+			verifyD(t, h, d)
+			RemoveD[int](&h, d, rand.Intn(h.Len()))
+			verifyD(t, h, d)
+		}
+		prev := PopD[int](&h, d)
+		for h.Len() > 0 {
+			x := PopD[int](&h, d)
+			if x < prev {
+				t.Fatalf("%d-ary pop got %d after %d", d, x, prev)
+			}
+			prev = x
+		}
+	}
+}
+
+func TestArityHeap(t *testing.T) {
+	h := NewFrom(func(a, b int) bool { return a < b }, rand.Perm(100), WithArity[int](4))
+	h.Push(-1)
+	if x := h.PushPop(50); x != -1 {
+		t.Fatalf("PushPop(50) = %d; want %d", x, -1)
+	}
+	d := h.Drain()
+	for i := 1; i < len(d); i++ {
+		if d[i] < d[i-1] {
+			t.Fatalf("Drain is not sorted at %d: %v", i, d)
+		}
+	}
+}
+
+func TestPairingHeap(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	h := NewPairing(less)
+	if _, ok := h.Pop(); ok {
+		t.Fatalf("Pop on an empty heap succeeded")
+	}
+	nodes := make([]*PairingNode[int], 0, 100)
+	for _, v := range rand.Perm(100) {
+		nodes = append(nodes, h.Push(v+1000))
+	}
+	for _, n := range nodes[:50] {
+		h.DecreaseKey(n, n.Value-1000)
+	}
+	other := NewPairing(less)
+	for i := 0; i < 20; i++ {
+		other.Push(500 + i)
+	}
+	h.Meld(other)
+	if other.Len() != 0 || h.Len() != 120 {
+		t.Fatalf("Meld got Len %d and %d; want %d and %d", h.Len(), other.Len(), 120, 0)
+	}
+
+	var got []int
+	for h.Len() > 0 {
+		x, _ := h.Pop()
+		got = append(got, x)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i] < got[i-1] {
+			t.Fatalf("pairing heap popped %d after %d", got[i], got[i-1])
+		}
+	}
+	if nodes[0].Value < 1000 && h.DecreaseKey(nodes[0], -1) {
+		t.Fatalf("DecreaseKey on a popped node succeeded")
+	}
+}
+
+const benchN = 10000
+
+func BenchmarkBinaryHeap(b *testing.B) {
+	data := rand.Perm(benchN)
+	h := make(myHeap, 0, benchN)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range data {
+			Push[int](&h, v)
+		}
+		for h.Len() > 0 {
+			Pop[int](&h)
+		}
+	}
+}
+
+func Benchmark4aryHeap(b *testing.B) {
+	data := rand.Perm(benchN)
+	h := make(myHeap, 0, benchN)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range data {
+			PushD[int](&h, 4, v)
+		}
+		for h.Len() > 0 {
+			PopD[int](&h, 4)
+		}
+	}
+}
+
+func BenchmarkPairingHeap(b *testing.B) {
+	data := rand.Perm(benchN)
+	h := NewPairing(func(a, b int) bool { return a < b })
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range data {
+			h.Push(v)
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+// indexedInts is a min-heap of values that tracks where each key sits,
+// so that decrease-key can use Fix.
+type indexedInts struct {
+	vals []int
+	keys []int
+	pos  []int
+}
+
+func (h *indexedInts) Len() int           { return len(h.keys) }
+func (h *indexedInts) Less(i, j int) bool { return h.vals[h.keys[i]] < h.vals[h.keys[j]] }
+func (h *indexedInts) Swap(i, j int) {
+	h.keys[i], h.keys[j] = h.keys[j], h.keys[i]
+	h.pos[h.keys[i]] = i
+	h.pos[h.keys[j]] = j
+}
+func (h *indexedInts) Push(k int) {
+	h.pos[k] = len(h.keys)
+	h.keys = append(h.keys, k)
+}
+func (h *indexedInts) Pop() int {
+	k := h.keys[len(h.keys)-1]
+	h.keys = h.keys[:len(h.keys)-1]
+	return k
+}
+
+func BenchmarkBinaryHeapDecreaseKey(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		h := &indexedInts{vals: make([]int, benchN), pos: make([]int, benchN)}
+		for k := 0; k < benchN; k++ {
+			h.vals[k] = benchN + k
+			Push[int](h, k)
+		}
+		for k := benchN - 1; k >= 0; k-- {
+			h.vals[k] = k
+			Fix[int](h, h.pos[k])
+		}
+		for h.Len() > 0 {
+			Pop[int](h)
+		}
+	}
+}
+
+func BenchmarkPairingHeapDecreaseKey(b *testing.B) {
+	nodes := make([]*PairingNode[int], benchN)
+	for i := 0; i < b.N; i++ {
+		h := NewPairing(func(a, b int) bool { return a < b })
+		for k := 0; k < benchN; k++ {
+			nodes[k] = h.Push(benchN + k)
+		}
+		for k := benchN - 1; k >= 0; k-- {
+			h.DecreaseKey(nodes[k], k)
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func TestTopK(t *testing.T) {
+	top := NewTopK(5, func(a, b int) bool { return a < b })
+	for _, v := range rand.Perm(100) {
+		top.Offer(v)
+	}
+	if top.Len() != 5 {
+		t.Fatalf("Len() = %d; want %d", top.Len(), 5)
+	}
+	if x, _ := top.Min(); x != 95 {
+		t.Fatalf("Min() = %d; want %d", x, 95)
+	}
+	want := []int{99, 98, 97, 96, 95}
+	for i, x := range top.Sorted() {
+		if x != want[i] {
+			t.Fatalf("Sorted() = %v; want %v", top.Sorted(), want)
+		}
+	}
+	if top.Offer(0) {
+		t.Fatalf("Offer(0) kept an item smaller than the top k")
+	}
+	top.Reset()
+	if _, ok := top.Min(); ok || top.Len() != 0 {
+		t.Fatalf("Reset did not discard the items")
+	}
+	if NewTopK(0, func(a, b int) bool { return a < b }).Offer(1) {
+		t.Fatalf("Offer on a zero TopK kept an item")
+	}
+}
+
+func TestRunningMedian(t *testing.T) {
+	m := NewRunningMedian[int]()
+	if _, ok := m.Median(); ok {
+		t.Fatalf("Median of an empty stream succeeded")
+	}
+	var seen []int
+	for _, v := range rand.Perm(101) {
+		m.Add(v)
+		seen = append(seen, v)
+		sorted := append([]int(nil), seen...)
+		slices.Sort(sorted)
+		n := len(sorted)
+		want := float64(sorted[n/2])
+		if n%2 == 0 {
+			want = float64(sorted[n/2-1]+sorted[n/2]) / 2
+		}
+		if got, _ := m.Median(); got != want {
+			t.Fatalf("Median of %v = %v; want %v", sorted, got, want)
+		}
+		if low, _ := m.Low(); low != sorted[(n-1)/2] {
+			t.Fatalf("Low of %v = %v; want %v", sorted, low, sorted[(n-1)/2])
+		}
+	}
+}
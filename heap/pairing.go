@@ -0,0 +1,157 @@
+package heap
+
+// PairingNode is an element of a PairingHeap. The *PairingNode returned by
+// Push is a handle that can be passed to DecreaseKey while it is in the heap.
+type PairingNode[T any] struct {
+	Value T
+
+	child, sibling *PairingNode[T]
+	// prev is the parent of the first child and the left sibling of the others.
+	prev *PairingNode[T]
+	// popped is set once the node has been popped.
+	popped bool
+}
+
+// PairingHeap is a heap ordered by a less function, kept as a tree of nodes.
+// Push, Meld and DecreaseKey take O(1) time and Pop takes O(log n) amortized
+// time, which makes it faster than a binary heap when keys are decreased often.
+type PairingHeap[T any] struct {
+	root *PairingNode[T]
+	len  int
+	less func(a, b T) bool
+	// pairs is scratch space reused by mergePairs.
+	pairs []*PairingNode[T]
+}
+
+// NewPairing returns an empty pairing heap ordered by less.
+func NewPairing[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less}
+}
+
+func (h *PairingHeap[T]) Len() int {
+	return h.len
+}
+
+// Push pushes x onto the heap and returns its node.
+func (h *PairingHeap[T]) Push(x T) *PairingNode[T] {
+	n := &PairingNode[T]{Value: x}
+	h.root = h.link(h.root, n)
+	h.len++
+	return n
+}
+
+// Peek returns the top element without removing it.
+// If the heap is empty, ok is false.
+func (h *PairingHeap[T]) Peek() (x T, ok bool) {
+	if h.root == nil {
+		return
+	}
+	return h.root.Value, true
+}
+
+// Pop removes and returns the top element.
+// If the heap is empty, ok is false.
+func (h *PairingHeap[T]) Pop() (x T, ok bool) {
+	root := h.root
+	if root == nil {
+		return
+	}
+	h.root = h.mergePairs(root.child)
+	if h.root != nil {
+		h.root.prev = nil
+	}
+	h.len--
+	root.child, root.popped = nil, true
+	return root.Value, true
+}
+
+// Meld moves every element of other into h, leaving other empty.
+// Both heaps must be ordered by the same less function.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == h || other.root == nil {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.len += other.len
+	other.root, other.len = nil, 0
+}
+
+// DecreaseKey replaces the value of node with x, which must not be ordered
+// after the current value. node must have been pushed onto h, or onto a heap
+// melded into h. It returns false if node has already been popped.
+func (h *PairingHeap[T]) DecreaseKey(node *PairingNode[T], x T) bool {
+	if node.popped {
+		return false
+	}
+	if h.less(node.Value, x) {
+		panic("heap: DecreaseKey with a value ordered after the current one")
+	}
+	node.Value = x
+	if node == h.root {
+		return true
+	}
+	// Cut the subtree rooted at node and link it back with the root.
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+	node.prev, node.sibling = nil, nil
+	h.root = h.link(h.root, node)
+	return true
+}
+
+// link makes the root ordered later the first child of the other and
+// returns the new root. Either argument may be nil.
+func (h *PairingHeap[T]) link(a, b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.Value, a.Value) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	a.sibling = nil
+	return a
+}
+
+// mergePairs combines a list of siblings into one tree using the standard
+// two-pass scheme: link pairs left to right, then fold right to left.
+func (h *PairingHeap[T]) mergePairs(first *PairingNode[T]) *PairingNode[T] {
+	if first == nil {
+		return nil
+	}
+	pairs := h.pairs[:0]
+	for first != nil {
+		a := first
+		b := a.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+		}
+		a.sibling, a.prev = nil, nil
+		if b != nil {
+			b.sibling, b.prev = nil, nil
+		}
+		pairs = append(pairs, h.link(a, b))
+	}
+	root := pairs[len(pairs)-1]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	clear(pairs)
+	h.pairs = pairs
+	return root
+}
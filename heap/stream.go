@@ -0,0 +1,128 @@
+package heap
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// TopK keeps the k largest items offered to it, as ordered by less.
+// It holds them in a min-heap of size k, so Offer takes O(log k) time.
+type TopK[T any] struct {
+	h lessSlice[T]
+	k int
+}
+
+// NewTopK returns a TopK keeping the k largest items according to less.
+func NewTopK[T any](k int, less func(a, b T) bool) *TopK[T] {
+	if k < 0 {
+		panic("heap: TopK with negative k")
+	}
+	return &TopK[T]{
+		h: lessSlice[T]{d: make([]T, 0, k), less: less},
+		k: k,
+	}
+}
+
+func (t *TopK[T]) Len() int {
+	return t.h.Len()
+}
+
+// Offer considers x for the top k. It reports whether x was kept.
+func (t *TopK[T]) Offer(x T) bool {
+	if t.h.Len() < t.k {
+		Push[T](&t.h, x)
+		return true
+	}
+	if t.k == 0 || !t.h.less(t.h.d[0], x) {
+		return false
+	}
+	t.h.d[0] = x
+	down[T](&t.h, 0, t.h.Len())
+	return true
+}
+
+// Min returns the smallest of the kept items, the one the next kept item
+// will evict once k items are kept. If no item is kept, ok is false.
+func (t *TopK[T]) Min() (x T, ok bool) {
+	if t.h.Len() == 0 {
+		return
+	}
+	return t.h.d[0], true
+}
+
+// Sorted returns the kept items from largest to smallest.
+// The TopK is unchanged.
+func (t *TopK[T]) Sorted() []T {
+	d := slices.Clone(t.h.d)
+	slices.SortFunc(d, func(a, b T) int {
+		switch {
+		case t.h.less(b, a):
+			return -1
+		case t.h.less(a, b):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return d
+}
+
+// Reset discards all kept items.
+func (t *TopK[T]) Reset() {
+	clear(t.h.d)
+	t.h.d = t.h.d[:0]
+}
+
+// RunningMedian tracks the median of a stream of numbers.
+// The lower half is kept in a MaxHeap and the upper half in a MinHeap, so
+// Add takes O(log n) time and Median O(1).
+type RunningMedian[T constraints.Integer | constraints.Float] struct {
+	lo MaxHeap[T]
+	hi MinHeap[T]
+}
+
+func NewRunningMedian[T constraints.Integer | constraints.Float]() *RunningMedian[T] {
+	return &RunningMedian[T]{}
+}
+
+func (m *RunningMedian[T]) Len() int {
+	return m.lo.Len() + m.hi.Len()
+}
+
+// Add adds x to the stream.
+func (m *RunningMedian[T]) Add(x T) {
+	if m.lo.Len() == 0 || x <= m.lo[0] {
+		Push[T](&m.lo, x)
+	} else {
+		Push[T](&m.hi, x)
+	}
+	// Keep len(lo) == len(hi) or len(lo) == len(hi)+1.
+	if m.lo.Len() > m.hi.Len()+1 {
+		Push[T](&m.hi, Pop[T](&m.lo))
+	} else if m.hi.Len() > m.lo.Len() {
+		Push[T](&m.lo, Pop[T](&m.hi))
+	}
+}
+
+// Median returns the median of the numbers added so far: the middle one for
+// an odd count, the mean of the two middle ones for an even count.
+// If no number was added, ok is false.
+func (m *RunningMedian[T]) Median() (median float64, ok bool) {
+	if m.lo.Len() == 0 {
+		return
+	}
+	if m.lo.Len() > m.hi.Len() {
+		return float64(m.lo[0]), true
+	}
+	return (float64(m.lo[0]) + float64(m.hi[0])) / 2, true
+}
+
+// Low returns the lower middle number: the median for an odd count, and the
+// smaller of the two middle numbers for an even count.
+// If no number was added, ok is false.
+func (m *RunningMedian[T]) Low() (x T, ok bool) {
+	if m.lo.Len() == 0 {
+		return
+	}
+	return m.lo[0], true
+}
@@ -0,0 +1,291 @@
+// n-ary tree
+package ntree
+
+import (
+	"errors"
+)
+
+// Node is a node of a tree with an ordered list of children. Each node has a
+// Name, by which paths address it below its parent; names need not be
+// unique, and a path follows the first child with each name.
+type Node[T any] struct {
+	Name   string
+	Val    T
+	Parent *Node[T]
+
+	children []*Node[T]
+}
+
+func NewNode[T any](name string, val T) *Node[T] {
+	return &Node[T]{Name: name, Val: val}
+}
+
+// Children returns the children of n in order. The slice must not be
+// modified.
+func (n *Node[T]) Children() []*Node[T] {
+	return n.children
+}
+
+// Child returns the first child named name, or nil if there is none.
+func (n *Node[T]) Child(name string) *Node[T] {
+	for _, c := range n.children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// AddChild appends child to the children of n, first removing it from its
+// current parent, and returns it. It panics if child is nil, n or an
+// ancestor of n.
+func (n *Node[T]) AddChild(child *Node[T]) *Node[T] {
+	if child == nil {
+		panic("ntree: AddChild(nil)")
+	}
+	if child.IsAncestorRelation(n) {
+		panic("ntree: AddChild would create a cycle")
+	}
+	child.Detach()
+	child.Parent = n
+	n.children = append(n.children, child)
+	return child
+}
+
+// RemoveChild removes child from the children of n. It reports whether
+// child was a child of n.
+func (n *Node[T]) RemoveChild(child *Node[T]) bool {
+	i := n.indexOf(child)
+	if i < 0 {
+		return false
+	}
+	copy(n.children[i:], n.children[i+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+	child.Parent = nil
+	return true
+}
+
+// Detach removes n from its parent, making it the root of its subtree.
+func (n *Node[T]) Detach() {
+	if n.Parent != nil {
+		n.Parent.RemoveChild(n)
+	}
+}
+
+func (n *Node[T]) indexOf(child *Node[T]) int {
+	if child == nil || child.Parent != n {
+		return -1
+	}
+	for i, c := range n.children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
+
+// Find returns the node reached from n by following the child with each
+// name in path in turn, or nil if there is none. An empty path returns n.
+func (n *Node[T]) Find(path ...string) *Node[T] {
+	for _, name := range path {
+		if n = n.Child(name); n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// Path returns the names from the root down to n, excluding the root's,
+// so that n.Root().Find(n.Path()...) finds n if names are unique.
+func (n *Node[T]) Path() []string {
+	path := make([]string, n.Depth())
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i] = n.Name
+		n = n.Parent
+	}
+	return path
+}
+
+// SkipSubtree is returned by a pre hook of Walk to skip the children of
+// the node.
+var SkipSubtree = errors.New("skip subtree")
+
+// SkipAll is returned by a hook of Walk to stop the walk without error.
+var SkipAll = errors.New("skip all")
+
+// Walk walks the subtree rooted at n depth-first, calling pre on each node
+// before its children and post after them. Either hook may be nil.
+//
+// If pre returns SkipSubtree, the children of the node and its post hook
+// are skipped. If a hook returns SkipAll, the walk stops and Walk returns
+// nil. Any other error stops the walk and is returned.
+func (n *Node[T]) Walk(pre, post func(node *Node[T]) error) error {
+	err := n.walk(pre, post)
+	if err == SkipAll || err == SkipSubtree {
+		return nil
+	}
+	return err
+}
+
+func (n *Node[T]) walk(pre, post func(node *Node[T]) error) error {
+	if pre != nil {
+		if err := pre(n); err != nil {
+			return err
+		}
+	}
+	for _, c := range n.children {
+		if err := c.walk(pre, post); err != nil && err != SkipSubtree {
+			return err
+		}
+	}
+	if post != nil {
+		if err := post(n); err != nil && err != SkipSubtree {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Node[T]) IsRoot() bool {
+	return n.Parent == nil
+}
+
+func (n *Node[T]) IsLeaf() bool {
+	return len(n.children) == 0
+}
+
+// Root returns the root of the tree holding n.
+func (n *Node[T]) Root() *Node[T] {
+	for n.Parent != nil {
+		n = n.Parent
+	}
+	return n
+}
+
+// Depth returns the number of edges from the root to n.
+func (n *Node[T]) Depth() int {
+	d := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		d++
+	}
+	return d
+}
+
+// MaxDepth returns the number of edges on the longest path from n down to
+// a leaf.
+func (n *Node[T]) MaxDepth() int {
+	d := 0
+	for _, c := range n.children {
+		d = max(d, c.MaxDepth()+1)
+	}
+	return d
+}
+
+// Sibling returns the other child of n's parent when the parent has
+// exactly two children, as tree.Node.Sibling does for a binary node, and
+// nil otherwise. Use Siblings, NextSibling and PrevSibling for other
+// shapes.
+func (n *Node[T]) Sibling() *Node[T] {
+	if n.Parent == nil || len(n.Parent.children) != 2 {
+		return nil
+	}
+	if n.Parent.children[0] == n {
+		return n.Parent.children[1]
+	}
+	return n.Parent.children[0]
+}
+
+// Siblings returns the other children of n's parent, in order.
+func (n *Node[T]) Siblings() []*Node[T] {
+	if n.Parent == nil {
+		return nil
+	}
+	res := make([]*Node[T], 0, len(n.Parent.children)-1)
+	for _, c := range n.Parent.children {
+		if c != n {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// NextSibling returns the child after n in its parent's children, or nil.
+func (n *Node[T]) NextSibling() *Node[T] {
+	if n.Parent == nil {
+		return nil
+	}
+	i := n.Parent.indexOf(n)
+	if i+1 < len(n.Parent.children) {
+		return n.Parent.children[i+1]
+	}
+	return nil
+}
+
+// PrevSibling returns the child before n in its parent's children, or nil.
+func (n *Node[T]) PrevSibling() *Node[T] {
+	if n.Parent == nil {
+		return nil
+	}
+	if i := n.Parent.indexOf(n); i > 0 {
+		return n.Parent.children[i-1]
+	}
+	return nil
+}
+
+func (n *Node[T]) IsChildRelation(node *Node[T]) bool {
+	return node != nil && node.Parent == n
+}
+
+func (n *Node[T]) IsSiblingRelation(node *Node[T]) bool {
+	return node != nil && node != n && n.Parent != nil && node.Parent == n.Parent
+}
+
+// IsAncestorRelation reports whether n is node or one of its ancestors.
+func (n *Node[T]) IsAncestorRelation(node *Node[T]) bool {
+	for ; node != nil; node = node.Parent {
+		if node == n {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDescendantRelation reports whether n is node or one of its descendants.
+func (n *Node[T]) IsDescendantRelation(node *Node[T]) bool {
+	return node.IsAncestorRelation(n)
+}
+
+// LowestCommonAncestor returns the deepest node that is an ancestor of both
+// n and other, counting each as its own ancestor, or nil if they are in
+// different trees.
+func (n *Node[T]) LowestCommonAncestor(other *Node[T]) *Node[T] {
+	if other == nil {
+		return nil
+	}
+	a, b := n.Depth(), other.Depth()
+	for ; a > b; a-- {
+		n = n.Parent
+	}
+	for ; b > a; b-- {
+		other = other.Parent
+	}
+	for n != other {
+		n, other = n.Parent, other.Parent
+	}
+	return n
+}
+
+// Clone returns a deep copy of the subtree rooted at n, with no parent.
+// Values are copied by assignment.
+func (n *Node[T]) Clone() *Node[T] {
+	c := &Node[T]{Name: n.Name, Val: n.Val}
+	if len(n.children) > 0 {
+		c.children = make([]*Node[T], len(n.children))
+		for i, child := range n.children {
+			c.children[i] = child.Clone()
+			c.children[i].Parent = c
+		}
+	}
+	return c
+}
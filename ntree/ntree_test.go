@@ -0,0 +1,233 @@
+package ntree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+// build returns the tree
+//
+//	root
+//	├── etc
+//	│   ├── hosts
+//	│   └── ssh
+//	│       └── config
+//	└── usr
+//	    └── bin
+func build() *Node[int] {
+	root := NewNode("root", 0)
+	etc := root.AddChild(NewNode("etc", 1))
+	etc.AddChild(NewNode("hosts", 2))
+	etc.AddChild(NewNode("ssh", 3)).AddChild(NewNode("config", 4))
+	root.AddChild(NewNode("usr", 5)).AddChild(NewNode("bin", 6))
+	return root
+}
+
+func names(nodes []*Node[int]) []string {
+	res := make([]string, len(nodes))
+	for i, n := range nodes {
+		res[i] = n.Name
+	}
+	return res
+}
+
+func TestFind(t *testing.T) {
+	root := build()
+	config := root.Find("etc", "ssh", "config")
+	if config == nil || config.Val != 4 {
+		t.Fatalf("etc/ssh/config should be found")
+	}
+	if root.Find() != root {
+		t.Errorf("empty path should find the node itself")
+	}
+	if root.Find("etc", "nope") != nil || root.Find("usr", "bin", "x") != nil {
+		t.Errorf("missing paths should not be found")
+	}
+	if p := config.Path(); !slices.Equal(p, []string{"etc", "ssh", "config"}) {
+		t.Errorf("path should be [etc ssh config], but got %v", p)
+	}
+	if config.Depth() != 3 || root.Depth() != 0 {
+		t.Errorf("depths should be 3 and 0, but got %d and %d", config.Depth(), root.Depth())
+	}
+	if root.MaxDepth() != 3 || config.MaxDepth() != 0 {
+		t.Errorf("max depths should be 3 and 0, but got %d and %d", root.MaxDepth(), config.MaxDepth())
+	}
+	if config.Root() != root {
+		t.Errorf("root of config should be root")
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	root := build()
+	etc, usr := root.Child("etc"), root.Child("usr")
+	ssh := etc.Child("ssh")
+
+	usr.AddChild(ssh)
+	if etc.Child("ssh") != nil || usr.Child("ssh") != ssh || ssh.Parent != usr {
+		t.Errorf("ssh should have moved to usr")
+	}
+	if n := names(usr.Children()); !slices.Equal(n, []string{"bin", "ssh"}) {
+		t.Errorf("usr's children should be [bin ssh], but got %v", n)
+	}
+	if !usr.RemoveChild(ssh) || ssh.Parent != nil || usr.RemoveChild(ssh) {
+		t.Errorf("ssh should be removed once")
+	}
+	if etc.RemoveChild(root) {
+		t.Errorf("removing a non-child should fail")
+	}
+
+	for _, c := range []struct {
+		name  string
+		child *Node[int]
+		want  string
+	}{
+		{"ancestor", root, "ntree: AddChild would create a cycle"},
+		{"nil", nil, "ntree: AddChild(nil)"},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != c.want {
+					t.Errorf("adding %s as a child should panic with %q, but got %v", c.name, c.want, r)
+				}
+			}()
+			etc.Child("hosts").AddChild(c.child)
+		}()
+	}
+}
+
+func TestWalk(t *testing.T) {
+	root := build()
+	var pre, post []string
+	err := root.Walk(func(n *Node[int]) error {
+		pre = append(pre, n.Name)
+		return nil
+	}, func(n *Node[int]) error {
+		post = append(post, n.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(pre, []string{"root", "etc", "hosts", "ssh", "config", "usr", "bin"}) {
+		t.Errorf("unexpected pre-order %v", pre)
+	}
+	if !slices.Equal(post, []string{"hosts", "config", "ssh", "etc", "bin", "usr", "root"}) {
+		t.Errorf("unexpected post-order %v", post)
+	}
+
+	pre, post = nil, nil
+	root.Walk(func(n *Node[int]) error {
+		pre = append(pre, n.Name)
+		if n.Name == "etc" {
+			return SkipSubtree
+		}
+		return nil
+	}, func(n *Node[int]) error {
+		post = append(post, n.Name)
+		return nil
+	})
+	if !slices.Equal(pre, []string{"root", "etc", "usr", "bin"}) {
+		t.Errorf("unexpected pre-order with skip %v", pre)
+	}
+	if !slices.Equal(post, []string{"bin", "usr", "root"}) {
+		t.Errorf("unexpected post-order with skip %v", post)
+	}
+
+	pre = nil
+	err = root.Walk(func(n *Node[int]) error {
+		pre = append(pre, n.Name)
+		if n.Name == "ssh" {
+			return SkipAll
+		}
+		return nil
+	}, nil)
+	if err != nil || !slices.Equal(pre, []string{"root", "etc", "hosts", "ssh"}) {
+		t.Errorf("SkipAll should stop at ssh, but got %v, %v", pre, err)
+	}
+
+	errStop := errors.New("stop")
+	err = root.Walk(nil, func(n *Node[int]) error {
+		if strings.HasPrefix(n.Name, "c") {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("Walk should return %v, but got %v", errStop, err)
+	}
+}
+
+func TestRelation(t *testing.T) {
+	root := build()
+	etc, usr := root.Child("etc"), root.Child("usr")
+	hosts, ssh := etc.Child("hosts"), etc.Child("ssh")
+	config, bin := ssh.Child("config"), usr.Child("bin")
+
+	if hosts.NextSibling() != ssh || ssh.PrevSibling() != hosts {
+		t.Errorf("hosts and ssh should be adjacent siblings")
+	}
+	if ssh.NextSibling() != nil || hosts.PrevSibling() != nil || root.NextSibling() != nil {
+		t.Errorf("first and last children should have no sibling beyond")
+	}
+	if hosts.Sibling() != ssh || ssh.Sibling() != hosts || etc.Sibling() != usr {
+		t.Errorf("unexpected Sibling of a node with one sibling")
+	}
+	if bin.Sibling() != nil || root.Sibling() != nil {
+		t.Errorf("only child and root should have no Sibling")
+	}
+	etc.AddChild(NewNode("extra", 8))
+	if hosts.Sibling() != nil {
+		t.Errorf("Sibling with several siblings should be nil")
+	}
+	etc.RemoveChild(etc.Child("extra"))
+	if n := names(etc.Siblings()); !slices.Equal(n, []string{"usr"}) {
+		t.Errorf("etc's siblings should be [usr], but got %v", n)
+	}
+	if !hosts.IsSiblingRelation(ssh) || hosts.IsSiblingRelation(hosts) || etc.IsSiblingRelation(ssh) {
+		t.Errorf("unexpected sibling relations")
+	}
+	if !root.IsAncestorRelation(config) || !etc.IsAncestorRelation(config) || usr.IsAncestorRelation(config) {
+		t.Errorf("unexpected ancestor relations")
+	}
+	if !config.IsDescendantRelation(etc) || config.IsDescendantRelation(usr) {
+		t.Errorf("unexpected descendant relations")
+	}
+	if !etc.IsChildRelation(ssh) || etc.IsChildRelation(config) {
+		t.Errorf("unexpected child relations")
+	}
+
+	for _, c := range []struct {
+		a, b, want *Node[int]
+	}{
+		{config, hosts, etc},
+		{config, bin, root},
+		{config, ssh, ssh},
+		{root, root, root},
+		{config, NewNode("other", 0), nil},
+	} {
+		if got := c.a.LowestCommonAncestor(c.b); got != c.want {
+			t.Errorf("LowestCommonAncestor(%s, %s) should be %v, but got %v", c.a.Name, c.b.Name, c.want, got)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	root := build()
+	etc := root.Child("etc")
+	c := etc.Clone()
+	if c.Parent != nil {
+		t.Errorf("clone should have no parent")
+	}
+	cc := c.Find("ssh", "config")
+	if cc == nil || cc == etc.Find("ssh", "config") || cc.Parent.Parent != c {
+		t.Fatalf("clone should deep-copy the subtree")
+	}
+	c.Child("hosts").Val = 100
+	c.AddChild(NewNode("new", 7))
+	if etc.Child("hosts").Val != 2 || etc.Child("new") != nil {
+		t.Errorf("changing the clone should not change the original")
+	}
+}
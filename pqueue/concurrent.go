@@ -0,0 +1,178 @@
+package pqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by Concurrent operations after Close.
+var ErrClosed = errors.New("pqueue: queue closed")
+
+// Concurrent is a priority queue that is safe for concurrent use by multiple
+// goroutines. Pop blocks until an item is available, and when a capacity is
+// set Push blocks until there is room.
+type Concurrent[P, T any] struct {
+	mu       sync.Mutex
+	pq       *PQueue[P, T]
+	capacity int
+	closed   bool
+	// notEmpty is closed and replaced when an item is pushed, waking the
+	// goroutines blocked in Pop, and notFull when an item is popped, waking
+	// those blocked in Push. Each is only signaled while someone waits on
+	// it, as counted by popWaiters and pushWaiters. Close signals both.
+	notEmpty, notFull       chan struct{}
+	popWaiters, pushWaiters int
+}
+
+type ConcurrentConf[P, T any] func(c *Concurrent[P, T])
+
+// WithCapacity bounds the number of queued items. Push blocks and TryPush
+// fails while the queue is full. A capacity of 0 means unbounded.
+func WithCapacity[P, T any](capacity int) ConcurrentConf[P, T] {
+	return func(c *Concurrent[P, T]) {
+		c.capacity = capacity
+	}
+}
+
+// NewConcurrent returns a concurrent queue that orders items like pq.
+// pq must not be used directly afterwards.
+func NewConcurrent[P, T any](pq *PQueue[P, T], conf ...ConcurrentConf[P, T]) *Concurrent[P, T] {
+	c := &Concurrent[P, T]{
+		pq:       pq,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+	for _, cf := range conf {
+		cf(c)
+	}
+	return c
+}
+
+func (c *Concurrent[P, T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pq.Len()
+}
+
+func broadcastLocked(ch *chan struct{}) {
+	close(*ch)
+	*ch = make(chan struct{})
+}
+
+// pushedLocked wakes the goroutines waiting for an item.
+func (c *Concurrent[P, T]) pushedLocked() {
+	if c.popWaiters > 0 {
+		broadcastLocked(&c.notEmpty)
+	}
+}
+
+// poppedLocked wakes the goroutines waiting for room.
+func (c *Concurrent[P, T]) poppedLocked() {
+	if c.pushWaiters > 0 {
+		broadcastLocked(&c.notFull)
+	}
+}
+
+func (c *Concurrent[P, T]) fullLocked() bool {
+	return c.capacity > 0 && c.pq.Len() >= c.capacity
+}
+
+// Push adds value with the given priority, blocking while the queue is full.
+// It returns ErrClosed if the queue is closed, or ctx.Err() if ctx is done
+// before there is room.
+func (c *Concurrent[P, T]) Push(ctx context.Context, priority P, value T) error {
+	c.mu.Lock()
+	for !c.closed && c.fullLocked() {
+		notFull := c.notFull
+		c.pushWaiters++
+		c.mu.Unlock()
+		select {
+		case <-notFull:
+		case <-ctx.Done():
+			c.mu.Lock()
+			c.pushWaiters--
+			c.mu.Unlock()
+			return ctx.Err()
+		}
+		c.mu.Lock()
+		c.pushWaiters--
+	}
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	c.pq.Push(priority, value)
+	c.pushedLocked()
+	return nil
+}
+
+// TryPush adds value with the given priority if the queue is open and not full.
+// It reports whether the value was added.
+func (c *Concurrent[P, T]) TryPush(priority P, value T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed || c.fullLocked() {
+		return false
+	}
+	c.pq.Push(priority, value)
+	c.pushedLocked()
+	return true
+}
+
+// Pop removes and returns the item ordered first, blocking until one is
+// available. Items queued before Close are still returned; once the queue
+// is closed and empty Pop returns ErrClosed. If ctx is done first, Pop
+// returns ctx.Err().
+func (c *Concurrent[P, T]) Pop(ctx context.Context) (priority P, value T, err error) {
+	c.mu.Lock()
+	for !c.closed && c.pq.Len() == 0 {
+		notEmpty := c.notEmpty
+		c.popWaiters++
+		c.mu.Unlock()
+		select {
+		case <-notEmpty:
+		case <-ctx.Done():
+			c.mu.Lock()
+			c.popWaiters--
+			c.mu.Unlock()
+			err = ctx.Err()
+			return
+		}
+		c.mu.Lock()
+		c.popWaiters--
+	}
+	defer c.mu.Unlock()
+	if c.pq.Len() == 0 {
+		err = ErrClosed
+		return
+	}
+	priority, value = c.pq.Pop()
+	c.poppedLocked()
+	return
+}
+
+// TryPop removes and returns the item ordered first if there is one.
+func (c *Concurrent[P, T]) TryPop() (priority P, value T, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pq.Len() == 0 {
+		return
+	}
+	priority, value = c.pq.Pop()
+	c.poppedLocked()
+	return priority, value, true
+}
+
+// Close closes the queue and wakes all blocked Push and Pop calls.
+// Further pushes fail with ErrClosed; pops drain the remaining items.
+func (c *Concurrent[P, T]) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	broadcastLocked(&c.notEmpty)
+	broadcastLocked(&c.notFull)
+}
@@ -1,38 +1,119 @@
 // PriorityQueue
 package pqueue
 
-import "github.com/zijiren233/gencontainer/heap"
+import (
+	"github.com/zijiren233/gencontainer/heap"
+	"golang.org/x/exp/constraints"
+)
 
-type Item[T any] struct {
-	priority int
+// Item is an element of a PQueue. The *Item returned by Push is a handle that
+// can be passed to Update, Remove and Contains while the item is queued.
+type Item[P, T any] struct {
+	priority P
 	value    T
+
+	// seq is the insertion order of the item, used to break ties in stable mode.
+	seq uint64
+	// index is the position of the item in the heap, or -1 once popped or removed.
+	index int
 }
 
-func newItem[T any](priority int, value T) *Item[T] {
-	return &Item[T]{priority, value}
+// Priority returns the priority of the item.
+func (i *Item[P, T]) Priority() P {
+	return i.priority
 }
 
-type PQueue[T any] struct {
-	heap heap.Interface[*Item[T]]
+// Value returns the value of the item.
+func (i *Item[P, T]) Value() T {
+	return i.value
 }
 
-func NewMinPriorityQueue[T any]() *PQueue[T] {
-	return &PQueue[T]{&minqueue[T]{}}
+// PQueue is a priority queue. Pop returns the item whose priority is ordered
+// first by the queue's comparator.
+type PQueue[P, T any] struct {
+	heap *queue[P, T]
+	seq  uint64
 }
 
-func NewMaxPriorityQueue[T any]() *PQueue[T] {
-	return &PQueue[T]{&maxqueue[T]{}}
+type PQueueConf[P, T any] func(pq *PQueue[P, T])
+
+// WithStable makes items with equal priorities pop in the order they were pushed.
+func WithStable[P, T any]() PQueueConf[P, T] {
+	return func(pq *PQueue[P, T]) {
+		pq.heap.stable = true
+	}
 }
 
-func (pq *PQueue[T]) Len() int {
+// NewPriorityQueue returns a queue that pops first the item whose priority
+// is less than all others according to less.
+func NewPriorityQueue[P, T any](less func(a, b P) bool, conf ...PQueueConf[P, T]) *PQueue[P, T] {
+	pq := &PQueue[P, T]{heap: &queue[P, T]{less: less}}
+	for _, c := range conf {
+		c(pq)
+	}
+	return pq
+}
+
+// NewMinPriorityQueue returns a queue that pops the lowest priority first.
+func NewMinPriorityQueue[P constraints.Ordered, T any](conf ...PQueueConf[P, T]) *PQueue[P, T] {
+	return NewPriorityQueue(func(a, b P) bool { return a < b }, conf...)
+}
+
+// NewMaxPriorityQueue returns a queue that pops the highest priority first.
+func NewMaxPriorityQueue[P constraints.Ordered, T any](conf ...PQueueConf[P, T]) *PQueue[P, T] {
+	return NewPriorityQueue(func(a, b P) bool { return a > b }, conf...)
+}
+
+func (pq *PQueue[P, T]) Len() int {
 	return pq.heap.Len()
 }
 
-func (pq *PQueue[T]) Push(priority int, value T) {
-	heap.Push(pq.heap, newItem(priority, value))
+// Push adds value with the given priority and returns its handle.
+func (pq *PQueue[P, T]) Push(priority P, value T) *Item[P, T] {
+	item := &Item[P, T]{priority: priority, value: value, seq: pq.seq, index: -1}
+	pq.seq++
+	heap.Push[*Item[P, T]](pq.heap, item)
+	return item
 }
 
-func (pq *PQueue[T]) Pop() (int, T) {
-	item := heap.Pop(pq.heap)
+// Pop removes and returns the item ordered first.
+// Pop panics if the queue is empty.
+func (pq *PQueue[P, T]) Pop() (P, T) {
+	item := heap.Pop[*Item[P, T]](pq.heap)
 	return item.priority, item.value
 }
+
+// Peek returns the item ordered first without removing it.
+// Peek panics if the queue is empty.
+func (pq *PQueue[P, T]) Peek() (P, T) {
+	item := pq.heap.at(0)
+	return item.priority, item.value
+}
+
+// Contains reports whether the item is queued in pq.
+// A nil item is never queued.
+func (pq *PQueue[P, T]) Contains(item *Item[P, T]) bool {
+	return item != nil && item.index >= 0 && item.index < pq.heap.Len() && pq.heap.at(item.index) == item
+}
+
+// Update changes the priority of a queued item.
+// In stable mode the item keeps its original insertion order.
+// If the item is not in pq, Update returns false.
+func (pq *PQueue[P, T]) Update(item *Item[P, T], priority P) bool {
+	if !pq.Contains(item) {
+		return false
+	}
+	item.priority = priority
+	heap.Fix[*Item[P, T]](pq.heap, item.index)
+	return true
+}
+
+// Remove removes a queued item.
+// If the item is not in pq, Remove returns false.
+func (pq *PQueue[P, T]) Remove(item *Item[P, T]) bool {
+	if !pq.Contains(item) {
+		return false
+	}
+	heap.Remove[*Item[P, T]](pq.heap, item.index)
+	return true
+}
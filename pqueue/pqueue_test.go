@@ -1,13 +1,16 @@
 package pqueue_test
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/zijiren233/gencontainer/pqueue"
 )
 
 func TestMinPQueue(t *testing.T) {
-	pq := pqueue.NewMinPriorityQueue[int]()
+	pq := pqueue.NewMinPriorityQueue[int, int]()
 	for i := 20; i > 0; i-- {
 		pq.Push(i, i)
 	}
@@ -23,7 +26,7 @@ func TestMinPQueue(t *testing.T) {
 }
 
 func TestMaxPQueue(t *testing.T) {
-	pq := pqueue.NewMaxPriorityQueue[int]()
+	pq := pqueue.NewMaxPriorityQueue[int, int]()
 	for i := 1; i <= 20; i++ {
 		pq.Push(i, i)
 	}
@@ -39,7 +42,7 @@ func TestMaxPQueue(t *testing.T) {
 }
 
 func TestBench(t *testing.T) {
-	pq := pqueue.NewMinPriorityQueue[int]()
+	pq := pqueue.NewMinPriorityQueue[int, int]()
 	for i := 1; i <= 1_000_000; i++ {
 		pq.Push(i, i)
 	}
@@ -53,3 +56,176 @@ func TestBench(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateRemove(t *testing.T) {
+	pq := pqueue.NewMinPriorityQueue[int, string]()
+	a := pq.Push(5, "a")
+	b := pq.Push(3, "b")
+	c := pq.Push(4, "c")
+	if priority, value := pq.Peek(); priority != 3 || value != "b" {
+		t.Errorf("Peek got %d:%s; want %d:%s", priority, value, 3, "b")
+	}
+	if !pq.Update(a, 1) {
+		t.Errorf("Update on a queued item failed")
+	}
+	if priority, value := pq.Peek(); priority != 1 || value != "a" {
+		t.Errorf("Peek after Update got %d:%s; want %d:%s", priority, value, 1, "a")
+	}
+	if !pq.Remove(b) || pq.Remove(b) || pq.Contains(b) {
+		t.Errorf("Remove failed")
+	}
+	if _, value := pq.Pop(); value != "a" {
+		t.Errorf("Pop got %s; want %s", value, "a")
+	}
+	if pq.Contains(a) || pq.Update(a, 0) {
+		t.Errorf("popped item is still contained")
+	}
+	if !pq.Contains(c) || pq.Len() != 1 {
+		t.Errorf("Contains(c) = %t, Len() = %d; want %t, %d", pq.Contains(c), pq.Len(), true, 1)
+	}
+	if pqueue.NewMinPriorityQueue[int, string]().Contains(c) {
+		t.Errorf("item is contained in another queue")
+	}
+	if pq.Contains(nil) || pq.Update(nil, 0) || pq.Remove(nil) {
+		t.Errorf("nil item is contained")
+	}
+}
+
+func TestStable(t *testing.T) {
+	pq := pqueue.NewMinPriorityQueue(pqueue.WithStable[float64, int]())
+	for i := 0; i < 100; i++ {
+		pq.Push(float64(i%3)/2, i)
+	}
+	last := map[float64]int{}
+	for pq.Len() > 0 {
+		priority, value := pq.Pop()
+		if prev, ok := last[priority]; ok && prev > value {
+			t.Fatalf("priority %v popped %d after %d", priority, value, prev)
+		}
+		last[priority] = value
+	}
+}
+
+func TestComparator(t *testing.T) {
+	now := time.Now()
+	pq := pqueue.NewPriorityQueue[time.Time, string](time.Time.Before)
+	pq.Push(now.Add(time.Second), "b")
+	pq.Push(now.Add(time.Minute), "c")
+	pq.Push(now, "a")
+	for _, want := range []string{"a", "b", "c"} {
+		if _, value := pq.Pop(); value != want {
+			t.Errorf("Pop got %s; want %s", value, want)
+		}
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	c := pqueue.NewConcurrent(pqueue.NewMinPriorityQueue[int, int]())
+	const (
+		producers = 4
+		n         = 1000
+	)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if err := c.Push(context.Background(), i, p*n+i); err != nil {
+					t.Errorf("Push failed: %v", err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	seen := make(map[int]bool)
+	for len(seen) < producers*n {
+		_, value, err := c.Pop(context.Background())
+		if err != nil {
+			t.Fatalf("Pop failed: %v", err)
+		}
+		if seen[value] {
+			t.Fatalf("value %d popped twice", value)
+		}
+		seen[value] = true
+	}
+	wg.Wait()
+	if _, _, ok := c.TryPop(); ok {
+		t.Fatalf("TryPop on an empty queue succeeded")
+	}
+}
+
+func TestConcurrentWaiters(t *testing.T) {
+	const waiters = 8
+	c := pqueue.NewConcurrent(pqueue.NewMinPriorityQueue[int, int](), pqueue.WithCapacity[int, int](1))
+	var wg sync.WaitGroup
+	popped := make(chan int, 2*waiters)
+	// Blocked poppers must each be woken by a push, and blocked pushers by a
+	// pop, with no wakeup lost between the two kinds of waiters.
+	for i := 0; i < waiters; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, v, err := c.Pop(context.Background())
+			if err != nil {
+				t.Errorf("Pop failed: %v", err)
+				return
+			}
+			popped <- v
+		}()
+		go func(i int) {
+			defer wg.Done()
+			if err := c.Push(context.Background(), i, i); err != nil {
+				t.Errorf("Push failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(popped)
+	seen := make(map[int]bool)
+	for v := range popped {
+		seen[v] = true
+	}
+	if len(seen) != waiters || c.Len() != 0 {
+		t.Fatalf("popped %d distinct values, %d left; want %d, 0", len(seen), c.Len(), waiters)
+	}
+}
+
+func TestConcurrentBlocking(t *testing.T) {
+	c := pqueue.NewConcurrent(pqueue.NewMinPriorityQueue[int, string](), pqueue.WithCapacity[int, string](1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := c.Pop(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Pop on an empty queue got %v; want %v", err, context.DeadlineExceeded)
+	}
+
+	if !c.TryPush(1, "a") || c.TryPush(2, "b") {
+		t.Fatalf("TryPush ignored the capacity")
+	}
+	done := make(chan error)
+	go func() {
+		done <- c.Push(context.Background(), 0, "c")
+	}()
+	if _, value, err := c.Pop(context.Background()); err != nil || value != "a" {
+		t.Fatalf("Pop got %s, %v; want %s", value, err, "a")
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("blocked Push failed: %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.Close()
+	}()
+	if err := c.Push(context.Background(), 0, "d"); err != pqueue.ErrClosed {
+		t.Fatalf("Push on a closed queue got %v; want %v", err, pqueue.ErrClosed)
+	}
+	if _, value, err := c.Pop(context.Background()); err != nil || value != "c" {
+		t.Fatalf("Pop after Close got %s, %v; want %s", value, err, "c")
+	}
+	if _, _, err := c.Pop(context.Background()); err != pqueue.ErrClosed {
+		t.Fatalf("Pop on a closed empty queue got %v; want %v", err, pqueue.ErrClosed)
+	}
+}
@@ -0,0 +1,52 @@
+package pqueue
+
+import "github.com/zijiren233/gencontainer/heap"
+
+var _ heap.Interface[*Item[int, int]] = (*queue[int, int])(nil)
+
+// queue is a heap of items ordered by a priority comparator and, in stable
+// mode, by insertion order among equal priorities.
+type queue[P, T any] struct {
+	items  []*Item[P, T]
+	less   func(a, b P) bool
+	stable bool
+}
+
+func (pq *queue[P, T]) Len() int {
+	return len(pq.items)
+}
+
+func (pq *queue[P, T]) Less(i, j int) bool {
+	a, b := pq.items[i], pq.items[j]
+	if pq.less(a.priority, b.priority) {
+		return true
+	}
+	if !pq.stable || pq.less(b.priority, a.priority) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func (pq *queue[P, T]) Swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+func (pq *queue[P, T]) Push(i *Item[P, T]) {
+	i.index = len(pq.items)
+	pq.items = append(pq.items, i)
+}
+
+func (pq *queue[P, T]) Pop() *Item[P, T] {
+	n := len(pq.items)
+	i := pq.items[n-1]
+	pq.items[n-1] = nil
+	i.index = -1
+	pq.items = pq.items[:n-1]
+	return i
+}
+
+func (pq *queue[P, T]) at(i int) *Item[P, T] {
+	return pq.items[i]
+}
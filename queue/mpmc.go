@@ -0,0 +1,126 @@
+package queue
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type cell[T any] struct {
+	// seq tells producers and consumers whose turn it is: a producer may
+	// fill the cell for position pos when seq == pos, and a consumer may
+	// empty it when seq == pos+1.
+	seq atomic.Uint64
+	val T
+}
+
+// MPMC is a bounded lock-free queue for any number of producer and consumer
+// goroutines, using Dmitry Vyukov's per-cell sequence numbers.
+type MPMC[T any] struct {
+	_ pad
+	// enq is the next position to enqueue at.
+	enq atomic.Uint64
+	_   pad
+	// deq is the next position to dequeue from.
+	deq   atomic.Uint64
+	_     pad
+	cells []cell[T]
+	mask  uint64
+}
+
+// NewMPMC returns an empty queue holding at least capacity elements.
+// The capacity is rounded up to a power of two, and to at least 2: with a
+// single cell, a filled cell's sequence number would equal the one marking
+// it free for the next lap.
+func NewMPMC[T any](capacity int) *MPMC[T] {
+	c := max(roundUp(capacity), 2)
+	q := &MPMC[T]{
+		cells: make([]cell[T], c),
+		mask:  c - 1,
+	}
+	for i := range q.cells {
+		q.cells[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+func (q *MPMC[T]) Cap() int {
+	return len(q.cells)
+}
+
+// Len returns the number of queued elements. It is only a snapshot while
+// the queue is in use, clamped to [0, Cap()] as the two indices are read
+// at different times.
+func (q *MPMC[T]) Len() int {
+	deq := q.deq.Load()
+	enq := q.enq.Load()
+	if enq < deq {
+		return 0
+	}
+	return int(min(enq-deq, uint64(len(q.cells))))
+}
+
+// TryEnqueue adds v at the tail. It returns false if the queue is full.
+func (q *MPMC[T]) TryEnqueue(v T) bool {
+	pos := q.enq.Load()
+	for {
+		c := &q.cells[pos&q.mask]
+		seq := c.seq.Load()
+		switch dif := int64(seq - pos); {
+		case dif == 0:
+			if q.enq.CompareAndSwap(pos, pos+1) {
+				c.val = v
+				c.seq.Store(pos + 1)
+				return true
+			}
+			pos = q.enq.Load()
+		case dif < 0:
+			// The cell still holds the element enqueued one lap ago.
+			return false
+		default:
+			pos = q.enq.Load()
+		}
+	}
+}
+
+// TryDequeue removes and returns the head element. If the queue is empty,
+// ok is false.
+func (q *MPMC[T]) TryDequeue() (v T, ok bool) {
+	pos := q.deq.Load()
+	for {
+		c := &q.cells[pos&q.mask]
+		seq := c.seq.Load()
+		switch dif := int64(seq - (pos + 1)); {
+		case dif == 0:
+			if q.deq.CompareAndSwap(pos, pos+1) {
+				var zero T
+				v, c.val = c.val, zero
+				c.seq.Store(pos + q.mask + 1)
+				return v, true
+			}
+			pos = q.deq.Load()
+		case dif < 0:
+			// The cell has not been filled for this lap yet.
+			return
+		default:
+			pos = q.deq.Load()
+		}
+	}
+}
+
+// Enqueue adds v at the tail, waiting while the queue is full.
+// It returns ctx.Err() if ctx is done first.
+func (q *MPMC[T]) Enqueue(ctx context.Context, v T) error {
+	return wait(ctx, func() bool {
+		return q.TryEnqueue(v)
+	})
+}
+
+// Dequeue removes and returns the head element, waiting while the queue
+// is empty. It returns ctx.Err() if ctx is done first.
+func (q *MPMC[T]) Dequeue(ctx context.Context) (v T, err error) {
+	err = wait(ctx, func() (ok bool) {
+		v, ok = q.TryDequeue()
+		return
+	})
+	return
+}
@@ -0,0 +1,56 @@
+// lock-free bounded queues
+package queue
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+// cacheLineSize is used to pad the hot indices of a queue onto separate
+// cache lines, so that producers and consumers don't false-share.
+const cacheLineSize = 64
+
+type pad [cacheLineSize]byte
+
+// roundUp returns the smallest power of two greater than or equal to n.
+func roundUp(n int) uint64 {
+	if n <= 0 {
+		panic("queue: capacity must be positive")
+	}
+	c := uint64(1)
+	for c < uint64(n) {
+		c <<= 1
+	}
+	return c
+}
+
+// wait retries try until it succeeds or ctx is done. It spins briefly, then
+// yields, then sleeps with exponential backoff, so a blocked call costs
+// little CPU while staying responsive under load.
+func wait(ctx context.Context, try func() bool) error {
+	const (
+		spins    = 16
+		yields   = 64
+		maxSleep = time.Millisecond
+	)
+	sleep := time.Microsecond
+	for i := 0; ; i++ {
+		if try() {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		switch {
+		case i < spins:
+		case i < spins+yields:
+			runtime.Gosched()
+		default:
+			time.Sleep(sleep)
+			if sleep < maxSleep {
+				sleep *= 2
+			}
+		}
+	}
+}
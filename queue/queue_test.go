@@ -0,0 +1,245 @@
+package queue
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSPSC(t *testing.T) {
+	q := NewSPSC[int](3)
+	if q.Cap() != 4 {
+		t.Fatalf("q.Cap() = %d, want %d", q.Cap(), 4)
+	}
+	if _, ok := q.TryDequeue(); ok {
+		t.Errorf("TryDequeue on an empty queue succeeded")
+	}
+	for i := 0; i < 4; i++ {
+		if !q.TryEnqueue(i) {
+			t.Fatalf("TryEnqueue(%d) failed", i)
+		}
+	}
+	if q.TryEnqueue(4) {
+		t.Errorf("TryEnqueue on a full queue succeeded")
+	}
+	if q.Len() != 4 {
+		t.Errorf("q.Len() = %d, want %d", q.Len(), 4)
+	}
+	for i := 0; i < 4; i++ {
+		if v, ok := q.TryDequeue(); !ok || v != i {
+			t.Fatalf("TryDequeue() = %d, %t, want %d, true", v, ok, i)
+		}
+	}
+}
+
+func TestMPMC(t *testing.T) {
+	q := NewMPMC[int](4)
+	if _, ok := q.TryDequeue(); ok {
+		t.Errorf("TryDequeue on an empty queue succeeded")
+	}
+	// Go around the ring a few times.
+	for lap := 0; lap < 3; lap++ {
+		for i := 0; i < 4; i++ {
+			if !q.TryEnqueue(lap*4 + i) {
+				t.Fatalf("TryEnqueue(%d) failed", lap*4+i)
+			}
+		}
+		if q.TryEnqueue(-1) {
+			t.Errorf("TryEnqueue on a full queue succeeded")
+		}
+		if q.Len() != 4 {
+			t.Errorf("q.Len() = %d, want %d", q.Len(), 4)
+		}
+		for i := 0; i < 4; i++ {
+			if v, ok := q.TryDequeue(); !ok || v != lap*4+i {
+				t.Fatalf("TryDequeue() = %d, %t, want %d, true", v, ok, lap*4+i)
+			}
+		}
+	}
+}
+
+func TestBlockingCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s := NewSPSC[int](1)
+	if _, err := s.Dequeue(ctx); err != context.DeadlineExceeded {
+		t.Errorf("SPSC.Dequeue() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	m := NewMPMC[int](1)
+	m.TryEnqueue(1)
+	m.TryEnqueue(2)
+	if err := m.Enqueue(ctx, 3); err != context.DeadlineExceeded {
+		t.Errorf("MPMC.Enqueue() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestLenConcurrent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := NewSPSC[int](4)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10000; i++ {
+			if q.Enqueue(ctx, i) != nil {
+				return
+			}
+		}
+	}()
+	go func() {
+		for {
+			if _, err := q.Dequeue(ctx); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if n := q.Len(); n < 0 || n > q.Cap() {
+			t.Fatalf("q.Len() = %d, want 0..%d", n, q.Cap())
+		}
+		runtime.Gosched()
+	}
+}
+
+const stressN = 100000
+
+func TestSPSCStress(t *testing.T) {
+	// Canceling ctx on return unblocks the producer if the test fails.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := NewSPSC[int](64)
+	go func() {
+		for i := 0; i < stressN; i++ {
+			if q.Enqueue(ctx, i) != nil {
+				return
+			}
+		}
+	}()
+	for i := 0; i < stressN; i++ {
+		v, err := q.Dequeue(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != i {
+			t.Fatalf("Dequeue() = %d, want %d", v, i)
+		}
+	}
+}
+
+func TestMPMCStress(t *testing.T) {
+	const producers, consumers = 4, 4
+	// A failing consumer cancels ctx, so that the other goroutines stop
+	// instead of blocking forever. Enqueue and Dequeue only fail then.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := NewMPMC[int](64)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := p; i < stressN; i += producers {
+				if q.Enqueue(ctx, i) != nil {
+					return
+				}
+			}
+		}(p)
+	}
+	seen := make([][]int, consumers)
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func(c int) {
+			defer cwg.Done()
+			last := make([]int, producers)
+			for i := range last {
+				last[i] = -1
+			}
+			for {
+				v, err := q.Dequeue(ctx)
+				if err != nil {
+					return
+				}
+				if v < 0 {
+					return
+				}
+				// Each consumer sees each producer's values in order.
+				p := v % producers
+				if v <= last[p] {
+					t.Errorf("consumer %d got %d after %d", c, v, last[p])
+					cancel()
+					return
+				}
+				last[p] = v
+				seen[c] = append(seen[c], v)
+			}
+		}(c)
+	}
+	wg.Wait()
+	for c := 0; c < consumers; c++ {
+		q.Enqueue(ctx, -1)
+	}
+	cwg.Wait()
+	if t.Failed() {
+		return
+	}
+	got := make([]bool, stressN)
+	n := 0
+	for _, s := range seen {
+		for _, v := range s {
+			if got[v] {
+				t.Fatalf("%d dequeued twice", v)
+			}
+			got[v] = true
+			n++
+		}
+	}
+	if n != stressN {
+		t.Fatalf("dequeued %d values, want %d", n, stressN)
+	}
+}
+
+func BenchmarkSPSC(b *testing.B) {
+	ctx := context.Background()
+	q := NewSPSC[int](1024)
+	go func() {
+		for i := 0; i < b.N; i++ {
+			q.Enqueue(ctx, i)
+		}
+	}()
+	for i := 0; i < b.N; i++ {
+		q.Dequeue(ctx)
+	}
+}
+
+func BenchmarkMPMC(b *testing.B) {
+	ctx := context.Background()
+	q := NewMPMC[int](1024)
+	go func() {
+		for i := 0; i < b.N; i++ {
+			q.Enqueue(ctx, i)
+		}
+	}()
+	for i := 0; i < b.N; i++ {
+		q.Dequeue(ctx)
+	}
+}
+
+func BenchmarkChannel(b *testing.B) {
+	c := make(chan int, 1024)
+	go func() {
+		for i := 0; i < b.N; i++ {
+			c <- i
+		}
+	}()
+	for i := 0; i < b.N; i++ {
+		<-c
+	}
+}
@@ -0,0 +1,93 @@
+package queue
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// SPSC is a bounded wait-free queue for exactly one producer goroutine and
+// one consumer goroutine. Using it from more than one producer or more than
+// one consumer at a time is a data race.
+type SPSC[T any] struct {
+	_ pad
+	// head is the index of the next element to dequeue. Only the consumer
+	// stores it.
+	head atomic.Uint64
+	_    pad
+	// tail is the index of the next slot to enqueue. Only the producer
+	// stores it.
+	tail atomic.Uint64
+	_    pad
+	buf  []T
+	mask uint64
+}
+
+// NewSPSC returns an empty queue holding at least capacity elements.
+// The capacity is rounded up to a power of two.
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	c := roundUp(capacity)
+	return &SPSC[T]{
+		buf:  make([]T, c),
+		mask: c - 1,
+	}
+}
+
+func (q *SPSC[T]) Cap() int {
+	return len(q.buf)
+}
+
+// Len returns the number of queued elements. It is only a snapshot while
+// the queue is in use, clamped to [0, Cap()] as the two indices are read
+// at different times.
+func (q *SPSC[T]) Len() int {
+	head := q.head.Load()
+	tail := q.tail.Load()
+	if tail < head {
+		return 0
+	}
+	return int(min(tail-head, uint64(len(q.buf))))
+}
+
+// TryEnqueue adds v at the tail. It returns false if the queue is full.
+// It must only be called by the producer.
+func (q *SPSC[T]) TryEnqueue(v T) bool {
+	t := q.tail.Load()
+	if t-q.head.Load() == uint64(len(q.buf)) {
+		return false
+	}
+	q.buf[t&q.mask] = v
+	q.tail.Store(t + 1)
+	return true
+}
+
+// TryDequeue removes and returns the head element. If the queue is empty,
+// ok is false. It must only be called by the consumer.
+func (q *SPSC[T]) TryDequeue() (v T, ok bool) {
+	h := q.head.Load()
+	if h == q.tail.Load() {
+		return
+	}
+	var zero T
+	i := h & q.mask
+	v, q.buf[i] = q.buf[i], zero
+	q.head.Store(h + 1)
+	return v, true
+}
+
+// Enqueue adds v at the tail, waiting while the queue is full.
+// It returns ctx.Err() if ctx is done first.
+func (q *SPSC[T]) Enqueue(ctx context.Context, v T) error {
+	return wait(ctx, func() bool {
+		return q.TryEnqueue(v)
+	})
+}
+
+// Dequeue removes and returns the head element, waiting while the queue
+// is empty. It returns ctx.Err() if ctx is done first.
+func (q *SPSC[T]) Dequeue(ctx context.Context) (v T, err error) {
+	err = wait(ctx, func() (ok bool) {
+		v, ok = q.TryDequeue()
+		return
+	})
+	return
+}
@@ -0,0 +1,179 @@
+package ring
+
+// Buffer is a fixed-capacity double-ended circular buffer backed by a slice.
+// Unlike Ring it knows its front and back, and Len and At run in O(1).
+//
+// When the buffer is full, a push either fails (the default) or, in
+// overwrite mode, evicts the element at the opposite end, which makes the
+// buffer keep the latest Cap elements pushed.
+type Buffer[T any] struct {
+	d         []T
+	head, len int
+	overwrite bool
+}
+
+type BufferConf[T any] func(b *Buffer[T])
+
+// WithOverwrite makes pushes to a full buffer evict the element at the
+// opposite end instead of failing.
+func WithOverwrite[T any]() BufferConf[T] {
+	return func(b *Buffer[T]) {
+		b.overwrite = true
+	}
+}
+
+// NewBuffer returns an empty buffer holding at most capacity elements.
+func NewBuffer[T any](capacity int, conf ...BufferConf[T]) *Buffer[T] {
+	if capacity <= 0 {
+		panic("ring: buffer capacity must be positive")
+	}
+	b := &Buffer[T]{d: make([]T, capacity)}
+	for _, c := range conf {
+		c(b)
+	}
+	return b
+}
+
+func (b *Buffer[T]) Len() int {
+	return b.len
+}
+
+func (b *Buffer[T]) Cap() int {
+	return len(b.d)
+}
+
+func (b *Buffer[T]) IsEmpty() bool {
+	return b.len == 0
+}
+
+func (b *Buffer[T]) IsFull() bool {
+	return b.len == len(b.d)
+}
+
+// index maps a logical position to an index of d.
+func (b *Buffer[T]) index(i int) int {
+	i += b.head
+	if i >= len(b.d) {
+		i -= len(b.d)
+	}
+	return i
+}
+
+// PushBack adds v at the back. If the buffer is full, it evicts the front
+// element in overwrite mode and otherwise returns false.
+func (b *Buffer[T]) PushBack(v T) bool {
+	if b.IsFull() {
+		if !b.overwrite {
+			return false
+		}
+		b.d[b.head] = v
+		b.head = b.index(1)
+		return true
+	}
+	b.d[b.index(b.len)] = v
+	b.len++
+	return true
+}
+
+// PushFront adds v at the front. If the buffer is full, it evicts the back
+// element in overwrite mode and otherwise returns false.
+func (b *Buffer[T]) PushFront(v T) bool {
+	if b.IsFull() && !b.overwrite {
+		return false
+	}
+	b.head--
+	if b.head < 0 {
+		b.head += len(b.d)
+	}
+	b.d[b.head] = v
+	if b.len < len(b.d) {
+		b.len++
+	}
+	return true
+}
+
+// PopFront removes and returns the front element.
+// If the buffer is empty, ok is false.
+func (b *Buffer[T]) PopFront() (v T, ok bool) {
+	if b.len == 0 {
+		return
+	}
+	var zero T
+	v, b.d[b.head] = b.d[b.head], zero
+	b.head = b.index(1)
+	b.len--
+	return v, true
+}
+
+// PopBack removes and returns the back element.
+// If the buffer is empty, ok is false.
+func (b *Buffer[T]) PopBack() (v T, ok bool) {
+	if b.len == 0 {
+		return
+	}
+	var zero T
+	i := b.index(b.len - 1)
+	v, b.d[i] = b.d[i], zero
+	b.len--
+	return v, true
+}
+
+// Front returns the front element without removing it.
+// If the buffer is empty, ok is false.
+func (b *Buffer[T]) Front() (v T, ok bool) {
+	if b.len == 0 {
+		return
+	}
+	return b.d[b.head], true
+}
+
+// Back returns the back element without removing it.
+// If the buffer is empty, ok is false.
+func (b *Buffer[T]) Back() (v T, ok bool) {
+	if b.len == 0 {
+		return
+	}
+	return b.d[b.index(b.len-1)], true
+}
+
+// At returns the i-th element from the front.
+// It panics if i is out of range.
+func (b *Buffer[T]) At(i int) T {
+	if i < 0 || i >= b.len {
+		panic("ring: index out of range")
+	}
+	return b.d[b.index(i)]
+}
+
+// Set replaces the i-th element from the front.
+// It panics if i is out of range.
+func (b *Buffer[T]) Set(i int, v T) {
+	if i < 0 || i >= b.len {
+		panic("ring: index out of range")
+	}
+	b.d[b.index(i)] = v
+}
+
+// Clear removes all elements.
+func (b *Buffer[T]) Clear() {
+	clear(b.d)
+	b.head, b.len = 0, 0
+}
+
+// Slice returns the elements from front to back.
+func (b *Buffer[T]) Slice() []T {
+	s := make([]T, b.len)
+	n := copy(s, b.d[b.head:min(b.head+b.len, len(b.d))])
+	copy(s[n:], b.d[:b.len-n])
+	return s
+}
+
+// Range calls f for each element from front to back with its position.
+// If f returns false, range stops the iteration.
+func (b *Buffer[T]) Range(f func(i int, v T) bool) {
+	for i := 0; i < b.len; i++ {
+		if !f(i, b.d[b.index(i)]) {
+			return
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package ring
+
+// List is a header for a ring that tracks its length, so Len takes O(1)
+// time, and a current element, which makes it suitable for round-robin
+// pickers. The zero List is empty and ready for use.
+//
+// The elements of a List must only be linked and unlinked through it.
+type List[T any] struct {
+	r   *Ring[T]
+	len int
+}
+
+// NewList returns a list holding values, with the first one current.
+func NewList[T any](values ...T) *List[T] {
+	l := &List[T]{}
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+func (l *List[T]) IsEmpty() bool {
+	return l.len == 0
+}
+
+// Ring returns the current element, or nil if the list is empty.
+// Its Value may be changed, but it must not be linked or unlinked directly.
+func (l *List[T]) Ring() *Ring[T] {
+	return l.r
+}
+
+// Value returns the value of the current element.
+// If the list is empty, ok is false.
+func (l *List[T]) Value() (v T, ok bool) {
+	if l.r == nil {
+		return
+	}
+	return l.r.Value, true
+}
+
+// Move moves the current element n elements forward, or backward if n is
+// negative, going the shorter way round. It takes O(min(|n| mod Len, Len/2))
+// time.
+func (l *List[T]) Move(n int) {
+	if l.len == 0 {
+		return
+	}
+	n %= l.len
+	if n > l.len/2 {
+		n -= l.len
+	} else if n < -l.len/2 {
+		n += l.len
+	}
+	l.r = l.r.Move(n)
+}
+
+// PushBack adds v just before the current element, at the end of a forward
+// iteration, and returns its element.
+func (l *List[T]) PushBack(v T) *Ring[T] {
+	e := &Ring[T]{Value: v}
+	if l.r == nil {
+		l.r = e.init()
+	} else {
+		l.r.Prev().Link(e.init())
+	}
+	l.len++
+	return e
+}
+
+// PushFront adds v just before the current element, makes it the current
+// element and returns it.
+func (l *List[T]) PushFront(v T) *Ring[T] {
+	l.r = l.PushBack(v)
+	return l.r
+}
+
+// Remove removes the current element and returns its value. The element
+// after it becomes current. If the list is empty, ok is false.
+func (l *List[T]) Remove() (v T, ok bool) {
+	if l.r == nil {
+		return
+	}
+	e := l.r
+	if l.len == 1 {
+		l.r = nil
+	} else {
+		l.r = e.next
+		e.prev.Unlink(1)
+	}
+	l.len--
+	return e.Value, true
+}
+
+// Link inserts the elements of other after the current element, keeping
+// their order and leaving other empty. It takes O(1) time.
+func (l *List[T]) Link(other *List[T]) {
+	if other == l || other.r == nil {
+		return
+	}
+	if l.r == nil {
+		l.r = other.r
+	} else {
+		l.r.Link(other.r)
+	}
+	l.len += other.len
+	other.r, other.len = nil, 0
+}
+
+// Unlink removes up to n elements starting with the one after the current
+// element, and returns them as a list along with how many were removed.
+// At most Len()-1 elements are removed; the current element is kept.
+func (l *List[T]) Unlink(n int) (*List[T], int) {
+	n = min(n, l.len-1)
+	if n <= 0 {
+		return &List[T]{}, 0
+	}
+	l.len -= n
+	return &List[T]{r: l.r.Unlink(n), len: n}, n
+}
+
+// Clear removes all elements.
+func (l *List[T]) Clear() {
+	l.r, l.len = nil, 0
+}
+
+// Do calls f on each element, in forward order from the current one.
+func (l *List[T]) Do(f func(T)) {
+	l.r.Do(f)
+}
+
+// DoWithIndex calls f on each element, in forward order from the current
+// one, with its distance from the current one.
+func (l *List[T]) DoWithIndex(f func(int, T)) {
+	l.r.DoWithIndex(f)
+}
+
+// Each calls f on each element, in forward order from the current one.
+// If f returns false, Each stops the iteration.
+func (l *List[T]) Each(f func(T) bool) {
+	l.r.Each(f)
+}
+
+// Slice returns the elements in forward order from the current one.
+func (l *List[T]) Slice() []T {
+	s := make([]T, 0, l.len)
+	l.r.Do(func(v T) {
+		s = append(s, v)
+	})
+	return s
+}
+
+// Reverse reverses the order of the elements in place. The current element
+// stays current.
+func (l *List[T]) Reverse() {
+	l.r.Reverse()
+}
@@ -96,3 +96,55 @@ func (r *Ring[T]) Do(f func(T)) {
 		}
 	}
 }
+
+// DoWithIndex calls f on each element of the ring, in forward order, with
+// its distance from r.
+func (r *Ring[T]) DoWithIndex(f func(int, T)) {
+	if r != nil {
+		f(0, r.Value)
+		i := 1
+		for p := r.Next(); p != r; p = p.next {
+			f(i, p.Value)
+			i++
+		}
+	}
+}
+
+// Each calls f on each element of the ring, in forward order.
+// If f returns false, Each stops the iteration.
+func (r *Ring[T]) Each(f func(T) bool) {
+	if r != nil {
+		if !f(r.Value) {
+			return
+		}
+		for p := r.Next(); p != r; p = p.next {
+			if !f(p.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the elements of the ring in forward order, starting with r.
+func (r *Ring[T]) Slice() []T {
+	var s []T
+	r.Do(func(v T) {
+		s = append(s, v)
+	})
+	return s
+}
+
+// Reverse reverses the direction of the ring in place, so that Next and
+// Prev swap for every element. r stays where it is.
+func (r *Ring[T]) Reverse() {
+	if r == nil {
+		return
+	}
+	p := r.Next()
+	r.next, r.prev = r.prev, r.next
+	for p != r {
+		n := p.next
+		p.next, p.prev = p.prev, p.next
+		p = n
+	}
+}
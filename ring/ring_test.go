@@ -2,6 +2,9 @@ package ring
 
 import (
 	"testing"
+	"time"
+
+	"golang.org/x/exp/slices"
 )
 
 func verify(t *testing.T, r *Ring[int], N int, sum int) {
@@ -199,3 +202,234 @@ func TestMoveEmptyRing(t *testing.T) {
 	r.Move(1)
 	verify(t, &r, 1, 0)
 }
+
+func checkBuffer(t *testing.T, b *Buffer[int], want ...int) {
+	t.Helper()
+	if b.Len() != len(want) {
+		t.Fatalf("b.Len() == %d; expected %d", b.Len(), len(want))
+	}
+	s := b.Slice()
+	for i, v := range want {
+		if s[i] != v || b.At(i) != v {
+			t.Fatalf("b.Slice() == %v, b.At(%d) == %d; expected %v", s, i, b.At(i), want)
+		}
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	b := NewBuffer[int](3)
+	if _, ok := b.PopFront(); ok {
+		t.Errorf("PopFront on an empty buffer succeeded")
+	}
+	b.PushBack(2)
+	b.PushBack(3)
+	b.PushFront(1)
+	checkBuffer(t, b, 1, 2, 3)
+	if b.PushBack(4) || b.PushFront(0) {
+		t.Errorf("push on a full buffer succeeded")
+	}
+	if v, _ := b.PopFront(); v != 1 {
+		t.Errorf("PopFront() == %d; expected %d", v, 1)
+	}
+	b.PushBack(4)
+	checkBuffer(t, b, 2, 3, 4)
+	if v, _ := b.PopBack(); v != 4 {
+		t.Errorf("PopBack() == %d; expected %d", v, 4)
+	}
+	b.PushFront(1)
+	checkBuffer(t, b, 1, 2, 3)
+	if v, _ := b.Front(); v != 1 {
+		t.Errorf("Front() == %d; expected %d", v, 1)
+	}
+	if v, _ := b.Back(); v != 3 {
+		t.Errorf("Back() == %d; expected %d", v, 3)
+	}
+	b.Set(1, 20)
+	checkBuffer(t, b, 1, 20, 3)
+
+	n := 0
+	b.Range(func(i, v int) bool {
+		n++
+		return i < 1
+	})
+	if n != 2 {
+		t.Errorf("Range visited %d elements after stopping; expected %d", n, 2)
+	}
+
+	b.Clear()
+	checkBuffer(t, b)
+}
+
+func TestBufferOverwrite(t *testing.T) {
+	b := NewBuffer(3, WithOverwrite[int]())
+	for i := 1; i <= 5; i++ {
+		b.PushBack(i)
+	}
+	checkBuffer(t, b, 3, 4, 5)
+	b.PushFront(2)
+	checkBuffer(t, b, 2, 3, 4)
+	if !b.IsFull() {
+		t.Errorf("b.IsFull() == false; expected true")
+	}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestWindow(t *testing.T) {
+	c := &fakeClock{now: time.Unix(1000, 0)}
+	w := NewWindow(10*time.Second, 10, WithClock(c))
+	for i := 0; i < 10; i++ {
+		w.Add(int64(i))
+		c.now = c.now.Add(time.Second)
+	}
+	// Now in a fresh bucket; the window holds 1..9.
+	if s := w.Sum(0); s != 45 {
+		t.Errorf("w.Sum(0) == %d; expected %d", s, 45)
+	}
+	if s := w.Sum(3 * time.Second); s != 8+9 {
+		t.Errorf("w.Sum(3s) == %d; expected %d", s, 8+9)
+	}
+	if s := w.Sum(2500 * time.Millisecond); s != 8+9 {
+		t.Errorf("w.Sum(2.5s) == %d; expected %d", s, 8+9)
+	}
+	if r := w.Rate(); r != 4.5 {
+		t.Errorf("w.Rate() == %v; expected %v", r, 4.5)
+	}
+
+	c.now = c.now.Add(5 * time.Second)
+	if s := w.Sum(0); s != 6+7+8+9 {
+		t.Errorf("w.Sum(0) == %d; expected %d", s, 6+7+8+9)
+	}
+	c.now = c.now.Add(time.Hour)
+	if s := w.Sum(0); s != 0 {
+		t.Errorf("w.Sum(0) after an idle hour == %d; expected 0", s)
+	}
+
+	w.Add(3)
+	c.now = c.now.Add(-time.Minute)
+	w.Add(4)
+	if s := w.Sum(time.Second); s != 7 {
+		t.Errorf("w.Sum(1s) after the clock went back == %d; expected %d", s, 7)
+	}
+	w.Reset()
+	if s := w.Sum(0); s != 0 {
+		t.Errorf("w.Sum(0) after Reset == %d; expected 0", s)
+	}
+}
+
+func TestRingHelpers(t *testing.T) {
+	r := makeN(5)
+	var idx []int
+	r.DoWithIndex(func(i, v int) {
+		if v != i+1 {
+			t.Errorf("DoWithIndex: element %d == %d; expected %d", i, v, i+1)
+		}
+		idx = append(idx, i)
+	})
+	if len(idx) != 5 {
+		t.Errorf("DoWithIndex visited %d elements; expected %d", len(idx), 5)
+	}
+	n := 0
+	r.Each(func(v int) bool {
+		n++
+		return v < 3
+	})
+	if n != 3 {
+		t.Errorf("Each visited %d elements after stopping; expected %d", n, 3)
+	}
+	if s := r.Slice(); !slices.Equal(s, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("r.Slice() == %v; expected %v", s, []int{1, 2, 3, 4, 5})
+	}
+	r.Reverse()
+	verify(t, r, 5, sumN(5))
+	if s := r.Slice(); !slices.Equal(s, []int{1, 5, 4, 3, 2}) {
+		t.Errorf("reversed r.Slice() == %v; expected %v", s, []int{1, 5, 4, 3, 2})
+	}
+	var r0 *Ring[int]
+	r0.Reverse()
+	if r0.Slice() != nil {
+		t.Errorf("nil ring Slice() != nil")
+	}
+}
+
+func checkList(t *testing.T, l *List[int], want ...int) {
+	t.Helper()
+	if l.Len() != len(want) {
+		t.Errorf("l.Len() == %d; expected %d", l.Len(), len(want))
+	}
+	verify(t, l.Ring(), len(want), -1)
+	if s := l.Slice(); !slices.Equal(s, want) {
+		t.Errorf("l.Slice() == %v; expected %v", s, want)
+	}
+}
+
+func TestList(t *testing.T) {
+	var l List[int]
+	checkList(t, &l)
+	if _, ok := l.Remove(); ok {
+		t.Errorf("Remove on an empty list succeeded")
+	}
+	l.Move(3)
+	l.PushBack(2)
+	l.PushBack(3)
+	l.PushFront(1)
+	checkList(t, &l, 1, 2, 3)
+
+	l.Move(4)
+	checkList(t, &l, 2, 3, 1)
+	l.Move(-1)
+	checkList(t, &l, 1, 2, 3)
+
+	// Round-robin picking.
+	var picked []int
+	for i := 0; i < 5; i++ {
+		v, _ := l.Value()
+		picked = append(picked, v)
+		l.Move(1)
+	}
+	if !slices.Equal(picked, []int{1, 2, 3, 1, 2}) {
+		t.Errorf("picked %v; expected %v", picked, []int{1, 2, 3, 1, 2})
+	}
+	checkList(t, &l, 3, 1, 2)
+
+	if v, _ := l.Remove(); v != 3 {
+		t.Errorf("l.Remove() == %d; expected %d", v, 3)
+	}
+	checkList(t, &l, 1, 2)
+
+	l.Link(NewList(7, 8, 9))
+	checkList(t, &l, 1, 7, 8, 9, 2)
+
+	u, n := l.Unlink(2)
+	if n != 2 {
+		t.Errorf("Unlink(2) removed %d; expected %d", n, 2)
+	}
+	checkList(t, u, 7, 8)
+	checkList(t, &l, 1, 9, 2)
+
+	u, n = l.Unlink(10)
+	if n != 2 {
+		t.Errorf("Unlink(10) removed %d; expected %d", n, 2)
+	}
+	checkList(t, u, 9, 2)
+	checkList(t, &l, 1)
+
+	u, n = l.Unlink(1)
+	if n != 0 {
+		t.Errorf("Unlink(1) on a single element removed %d; expected 0", n)
+	}
+	checkList(t, u)
+
+	l.Link(NewList(2, 3, 4))
+	l.Reverse()
+	checkList(t, &l, 1, 4, 3, 2)
+	l.Remove()
+	l.Clear()
+	checkList(t, &l)
+}
@@ -0,0 +1,155 @@
+package ring
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time used by a Window.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+type bucket struct {
+	// idx is the number of bucket widths from the Unix epoch to the start of
+	// the bucket.
+	idx   int64
+	count int64
+}
+
+// Window is a sliding window counter, such as for rate limiters and QPS
+// metrics. The window is split into a fixed ring of buckets, so Add takes
+// O(1) amortized time and memory does not grow with the event rate. Counts
+// expire a whole bucket at a time, which bounds the error of Sum and Rate
+// by the count of one bucket.
+//
+// A Window is safe for concurrent use.
+type Window struct {
+	mu sync.Mutex
+	// cur is the bucket of the latest time seen. The buckets before it, in
+	// Prev order, cover the rest of the window.
+	cur     *Ring[bucket]
+	buckets int
+	width   time.Duration
+	clock   Clock
+}
+
+type WindowConf func(w *Window)
+
+// WithClock makes the window read time from c instead of the system clock.
+func WithClock(c Clock) WindowConf {
+	return func(w *Window) {
+		w.clock = c
+	}
+}
+
+// NewWindow returns a window covering size, split into the given number of
+// buckets. size must be a positive multiple of buckets nanoseconds.
+func NewWindow(size time.Duration, buckets int, conf ...WindowConf) *Window {
+	if buckets <= 0 {
+		panic("ring: window bucket count must be positive")
+	}
+	if size <= 0 || size%time.Duration(buckets) != 0 {
+		panic("ring: window size must be a positive multiple of the bucket count")
+	}
+	w := &Window{
+		buckets: buckets,
+		width:   size / time.Duration(buckets),
+		clock:   realClock{},
+	}
+	for _, c := range conf {
+		c(w)
+	}
+	w.cur = New[bucket](buckets)
+	w.cur.Value.idx = w.index(w.clock.Now())
+	return w
+}
+
+// Size returns the duration covered by the window.
+func (w *Window) Size() time.Duration {
+	return w.width * time.Duration(w.buckets)
+}
+
+func (w *Window) index(t time.Time) int64 {
+	return t.UnixNano() / int64(w.width)
+}
+
+// advance moves cur forward to the bucket holding now, resetting the buckets
+// it passes. If the clock went backwards, cur is kept.
+func (w *Window) advance() {
+	idx := w.index(w.clock.Now())
+	steps := idx - w.cur.Value.idx
+	if steps <= 0 {
+		return
+	}
+	if steps > int64(w.buckets) {
+		steps = int64(w.buckets)
+	}
+	for i := steps - 1; i >= 0; i-- {
+		w.cur = w.cur.Next()
+		w.cur.Value = bucket{idx: idx - i}
+	}
+}
+
+// Add adds n to the current bucket.
+func (w *Window) Add(n int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.advance()
+	w.cur.Value.count += n
+}
+
+// Sum returns the total added during the last d, rounded up to whole
+// buckets. If d is not positive or exceeds the window size, it returns the
+// total of the whole window.
+func (w *Window) Sum(d time.Duration) int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.advance()
+	n := w.buckets
+	if d > 0 && d < w.Size() {
+		n = int((d + w.width - 1) / w.width)
+	}
+	return w.sum(n)
+}
+
+// sum returns the total of the latest n buckets.
+func (w *Window) sum(n int) int64 {
+	var s int64
+	oldest := w.cur.Value.idx - int64(n)
+	for p, i := w.cur, 0; i < n; p, i = p.Prev(), i+1 {
+		// Buckets not reached since the window was created or idle are stale.
+		if p.Value.idx <= oldest {
+			break
+		}
+		s += p.Value.count
+	}
+	return s
+}
+
+// Rate returns the total of the whole window per second.
+func (w *Window) Rate() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.advance()
+	return float64(w.sum(w.buckets)) / w.Size().Seconds()
+}
+
+// Reset discards all counts.
+func (w *Window) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	idx := w.index(w.clock.Now())
+	p := w.cur
+	for i := 0; i < w.buckets; i++ {
+		p.Value = bucket{}
+		p = p.Next()
+	}
+	w.cur.Value.idx = idx
+}
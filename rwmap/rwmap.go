@@ -58,6 +58,8 @@ type RWMap[K comparable, V any] struct {
 	// making a shallow copy of the clean map, omitting stale entries.
 	dirty map[K]*entry[V]
 
+	// len is the number of entries holding a value. It is updated atomically
+	// by every operation that adds or removes an entry.
 	len int64
 
 	// misses counts the number of loads since the read map was last updated that
@@ -68,6 +70,16 @@ type RWMap[K comparable, V any] struct {
 	// state) and the next store to the map will make a new dirty copy.
 	misses int
 	mu     sync.Mutex
+
+	// computing holds the in-flight LoadOrCompute calls, keyed by the key
+	// being computed. It is guarded by mu.
+	computing map[K]*computeCall[V]
+
+	// watchers are the subscribers registered by Watch. nwatchers mirrors
+	// len(watchers) so that writes can skip notification without locking.
+	watchMu   sync.Mutex
+	watchers  []*watcher[K, V]
+	nwatchers atomic.Int32
 }
 
 var (
@@ -208,6 +220,7 @@ func (m *RWMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
 	defer func() {
 		if !loaded {
 			atomic.AddInt64(&m.len, 1)
+			m.notifyPut(key, *new(V), false, actual)
 		}
 	}()
 	// Avoid locking if it's a clean hit.
@@ -282,6 +295,7 @@ func (m *RWMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
 	defer func() {
 		if loaded {
 			atomic.AddInt64(&m.len, -1)
+			m.notifyDelete(key, value)
 		}
 	}()
 	read := m.loadReadOnly()
@@ -346,6 +360,7 @@ func (m *RWMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
 		if !loaded {
 			atomic.AddInt64(&m.len, 1)
 		}
+		m.notifyPut(key, previous, loaded, value)
 	}()
 	read := m.loadReadOnly()
 	if e, ok := read.m[key]; ok {
@@ -391,6 +406,11 @@ func (m *RWMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
 // if the value stored in the map is equal to old.
 // The old value must be of a comparable type.
 func (m *RWMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	defer func() {
+		if swapped {
+			m.notifyPut(key, old, true, new)
+		}
+	}()
 	read := m.loadReadOnly()
 	if e, ok := read.m[key]; ok {
 		return e.tryCompareAndSwap(old, new)
@@ -426,6 +446,7 @@ func (m *RWMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
 	defer func() {
 		if deleted {
 			atomic.AddInt64(&m.len, -1)
+			m.notifyDelete(key, old)
 		}
 	}()
 	read := m.loadReadOnly()
@@ -460,6 +481,181 @@ func (m *RWMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
 	return false
 }
 
+// ComputeOp tells Compute what to do with the value returned by its callback.
+type ComputeOp int
+
+const (
+	// KeepOp leaves the entry unchanged.
+	KeepOp ComputeOp = iota
+	// StoreOp stores the returned value for the key.
+	StoreOp
+	// DeleteOp deletes the entry for the key.
+	DeleteOp
+)
+
+// Compute atomically reads, modifies and writes the value for a key.
+//
+// f is called with the current value and whether it was present, and returns
+// the new value together with the operation to apply. The operation is applied
+// only if the entry has not been changed concurrently since f observed it;
+// otherwise f is called again with the fresh value, so f may run more than once
+// and must be free of side effects. f must not call methods on m for the same key.
+//
+// Compute returns the value present in the map after the operation and whether
+// it is present.
+func (m *RWMap[K, V]) Compute(key K, f func(old V, loaded bool) (new V, op ComputeOp)) (actual V, ok bool) {
+	for {
+		read := m.loadReadOnly()
+		e, found := read.m[key]
+		dirty := false
+		if !found && read.amended {
+			m.mu.Lock()
+			read = m.loadReadOnly()
+			e, found = read.m[key]
+			if !found && read.amended {
+				e, found = m.dirty[key]
+				dirty = found
+				m.missLocked()
+			}
+			m.mu.Unlock()
+		}
+		if found {
+			if actual, ok, done := m.tryCompute(key, e, dirty, f); done {
+				return actual, ok
+			}
+		}
+
+		// The key is absent (or its entry has been expunged or dropped from the
+		// dirty map): compute the value without holding mu and insert it only if
+		// nobody else did in the meantime.
+		var zero V
+		value, op := f(zero, false)
+		if op != StoreOp {
+			return zero, false
+		}
+		if _, loaded := m.LoadOrStore(key, value); !loaded {
+			return value, true
+		}
+	}
+}
+
+// tryCompute applies f to the entry if it has not been expunged.
+//
+// If the entry is expunged, or dirty is set and the entry has been dropped
+// from the dirty map, tryCompute leaves the entry unchanged and returns with
+// done==false.
+func (m *RWMap[K, V]) tryCompute(key K, e *entry[V], dirty bool, f func(old V, loaded bool) (new V, op ComputeOp)) (actual V, ok, done bool) {
+	for {
+		p := e.p.Load()
+		if uintptr(unsafe.Pointer(p)) == expungedUintptr {
+			return
+		}
+		var old V
+		loaded := p != nil
+		if loaded {
+			old = *p
+		}
+		value, op := f(old, loaded)
+		switch op {
+		case StoreOp:
+			swapped, reachable := m.storeEntry(key, e, dirty, p, &value)
+			if !reachable {
+				return
+			}
+			if swapped {
+				if !loaded {
+					atomic.AddInt64(&m.len, 1)
+				}
+				m.notifyPut(key, old, loaded, value)
+				return value, true, true
+			}
+		case DeleteOp:
+			if !loaded {
+				return old, false, true
+			}
+			if e.p.CompareAndSwap(p, nil) {
+				atomic.AddInt64(&m.len, -1)
+				m.notifyDelete(key, old)
+				var zero V
+				return zero, false, true
+			}
+		default:
+			return old, loaded, true
+		}
+	}
+}
+
+// storeEntry swaps value into e if e still holds p.
+//
+// An entry loaded from read.m stays reachable until it is expunged, which
+// the swap detects, so it is swapped without locking. An entry loaded only
+// from the dirty map may be dropped from it by a concurrent LoadAndDelete,
+// after which a value stored into it would be lost; so it is swapped while
+// holding m.mu, and only if it is still in the map. reachable is false if
+// it is not.
+func (m *RWMap[K, V]) storeEntry(key K, e *entry[V], dirty bool, p, value *V) (swapped, reachable bool) {
+	if !dirty {
+		return e.p.CompareAndSwap(p, value), true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.loadReadOnly().m[key] != e && m.dirty[key] != e {
+		return false, false
+	}
+	return e.p.CompareAndSwap(p, value), true
+}
+
+type computeCall[V any] struct {
+	wg sync.WaitGroup
+}
+
+// LoadOrCompute returns the existing value for the key if present.
+// Otherwise, it calls valueFn, stores and returns its result.
+// The loaded result is true if the value was loaded, false if stored.
+//
+// Concurrent LoadOrCompute calls for the same key wait for each other, so
+// valueFn is called at most once while the key is absent. If the key is
+// stored by another method while valueFn runs, that value wins and is returned.
+// valueFn must not call LoadOrCompute for the same key, which would wait for
+// itself forever.
+func (m *RWMap[K, V]) LoadOrCompute(key K, valueFn func() V) (actual V, loaded bool) {
+	for {
+		if v, ok := m.Load(key); ok {
+			return v, true
+		}
+
+		m.mu.Lock()
+		if c, ok := m.computing[key]; ok {
+			m.mu.Unlock()
+			c.wg.Wait()
+			continue
+		}
+		if m.computing == nil {
+			m.computing = make(map[K]*computeCall[V])
+		}
+		c := &computeCall[V]{}
+		c.wg.Add(1)
+		m.computing[key] = c
+		m.mu.Unlock()
+
+		return m.doCompute(key, c, valueFn)
+	}
+}
+
+func (m *RWMap[K, V]) doCompute(key K, c *computeCall[V], valueFn func() V) (actual V, loaded bool) {
+	defer func() {
+		m.mu.Lock()
+		delete(m.computing, key)
+		m.mu.Unlock()
+		c.wg.Done()
+	}()
+	// The key may have been stored between the Load and the registration.
+	if v, ok := m.Load(key); ok {
+		return v, true
+	}
+	return m.LoadOrStore(key, valueFn())
+}
+
 // Range calls f sequentially for each key and value present in the map.
 // If f returns false, range stops the iteration.
 //
@@ -472,6 +668,14 @@ func (m *RWMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
 // Range may be O(N) with the number of elements in the map even if f returns
 // false after a constant number of calls.
 func (m *RWMap[K, V]) Range(f func(key K, value V) bool) {
+	m.rangeEntries(func(key K, _ *entry[V], p *V) bool {
+		return f(key, *p)
+	})
+}
+
+// rangeEntries calls f for each live entry along with the value pointer it
+// held when visited, so callers can act on the entry only if it is unchanged.
+func (m *RWMap[K, V]) rangeEntries(f func(key K, e *entry[V], p *V) bool) {
 	// We need to be able to iterate over all of the keys that were already
 	// present at the start of the call to Range.
 	// If read.amended is false, then read.m satisfies that property without
@@ -495,11 +699,11 @@ func (m *RWMap[K, V]) Range(f func(key K, value V) bool) {
 	}
 
 	for k, e := range read.m {
-		v, ok := e.load()
-		if !ok {
+		p := e.p.Load()
+		if p == nil || uintptr(unsafe.Pointer(p)) == expungedUintptr {
 			continue
 		}
-		if !f(k, v) {
+		if !f(k, e, p) {
 			break
 		}
 	}
@@ -507,6 +711,24 @@ func (m *RWMap[K, V]) Range(f func(key K, value V) bool) {
 
 // Clear deletes all the entries, resulting in an empty Map.
 func (m *RWMap[K, V]) Clear() {
+	m.clear(nil)
+}
+
+// LoadAndDeleteAll deletes all the entries and returns them.
+//
+// Unlike a Range followed by deletes, the returned entries and the emptied map
+// form a consistent snapshot: every entry is either returned or survives a
+// concurrent write that happened after LoadAndDeleteAll.
+func (m *RWMap[K, V]) LoadAndDeleteAll() map[K]V {
+	all := make(map[K]V)
+	m.clear(func(key K, value V) {
+		all[key] = value
+	})
+	return all
+}
+
+// clear empties the map, calling deleted (if not nil) for every entry removed.
+func (m *RWMap[K, V]) clear(deleted func(key K, value V)) {
 	read := m.loadReadOnly()
 	if len(read.m) == 0 && !read.amended {
 		// Avoid allocating a new readOnly when the map is already clear.
@@ -517,6 +739,25 @@ func (m *RWMap[K, V]) Clear() {
 	defer m.mu.Unlock()
 
 	read = m.loadReadOnly()
+	// Expunge every entry of the old maps so that concurrent operations still
+	// holding them fall back to the locked path and observe the cleared map,
+	// instead of silently writing to an entry that is no longer reachable.
+	var removed int64
+	expunge := func(k K, e *entry[V]) {
+		if p := e.expungeLocked(); p != nil {
+			removed++
+			m.notifyDelete(k, *p)
+			if deleted != nil {
+				deleted(k, *p)
+			}
+		}
+	}
+	for k, e := range read.m {
+		expunge(k, e)
+	}
+	for k, e := range m.dirty {
+		expunge(k, e)
+	}
 	if len(read.m) > 0 || read.amended {
 		m.read.Store(&readOnly[K, V]{})
 	}
@@ -524,13 +765,96 @@ func (m *RWMap[K, V]) Clear() {
 	clear(m.dirty)
 	// Don't immediately promote the newly-cleared dirty map on the next operation.
 	m.misses = 0
-	m.len = 0
+	atomic.AddInt64(&m.len, -removed)
+}
+
+// Snapshot returns a copy of the map's contents.
+//
+// Like Range, Snapshot does not necessarily correspond to any consistent
+// snapshot of the map if it is modified concurrently.
+func (m *RWMap[K, V]) Snapshot() map[K]V {
+	s := make(map[K]V, m.Len())
+	m.Range(func(key K, value V) bool {
+		s[key] = value
+		return true
+	})
+	return s
+}
+
+// Keys returns the keys of the map in an indeterminate order.
+func (m *RWMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	m.Range(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
 }
 
+// Values returns the values of the map in an indeterminate order.
+func (m *RWMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Len())
+	m.Range(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// StoreAll sets the value for every key in src.
+func (m *RWMap[K, V]) StoreAll(src map[K]V) {
+	for k, v := range src {
+		m.Store(k, v)
+	}
+}
+
+// DeleteFunc deletes every entry for which f returns true and returns the
+// number of entries deleted.
+//
+// An entry is deleted only if its value is still the one passed to f; if it is
+// stored concurrently in the meantime, the new value is kept.
+func (m *RWMap[K, V]) DeleteFunc(f func(key K, value V) bool) (n int) {
+	m.deleteFunc(f, func(K, V) {
+		n++
+	})
+	return
+}
+
+// LoadAndDeleteFunc deletes every entry for which f returns true and returns
+// the entries deleted.
+//
+// An entry is deleted only if its value is still the one passed to f; if it is
+// stored concurrently in the meantime, the new value is kept and the entry is
+// not returned.
+func (m *RWMap[K, V]) LoadAndDeleteFunc(f func(key K, value V) bool) map[K]V {
+	all := make(map[K]V)
+	m.deleteFunc(f, func(key K, value V) {
+		all[key] = value
+	})
+	return all
+}
+
+func (m *RWMap[K, V]) deleteFunc(f func(key K, value V) bool, deleted func(key K, value V)) {
+	m.rangeEntries(func(key K, e *entry[V], p *V) bool {
+		if f(key, *p) && e.p.CompareAndSwap(p, nil) {
+			atomic.AddInt64(&m.len, -1)
+			m.notifyDelete(key, *p)
+			deleted(key, *p)
+		}
+		return true
+	})
+}
+
+// Len returns the number of entries in the map.
+//
+// Len runs in constant time. Every operation adjusts the count by exactly the
+// number of entries it added or removed, so once concurrent writes have
+// returned Len equals the number of entries Range would visit.
 func (m *RWMap[K, V]) Len() (n int64) {
 	return atomic.LoadInt64(&m.len)
 }
 
+// SlowLen counts the entries in the map by ranging over it.
 func (m *RWMap[K, V]) SlowLen() (n int64) {
 	m.Range(func(_ K, _ V) bool {
 		n++
@@ -563,6 +887,20 @@ func (m *RWMap[K, V]) dirtyLocked() {
 	}
 }
 
+// expungeLocked unconditionally marks the entry as expunged.
+// It returns the value the entry held, or nil if it held none.
+func (e *entry[V]) expungeLocked() (old *V) {
+	for {
+		p := e.p.Load()
+		if uintptr(unsafe.Pointer(p)) == expungedUintptr {
+			return nil
+		}
+		if e.p.CompareAndSwap(p, (*V)(expunged)) {
+			return p
+		}
+	}
+}
+
 func (e *entry[V]) tryExpungeLocked() (isExpunged bool) {
 	p := e.p.Load()
 	for p == nil {
@@ -1,6 +1,7 @@
 package rwmap_test
 
 import (
+	"context"
 	"math/rand"
 	"reflect"
 	"runtime"
@@ -224,3 +225,290 @@ func TestLen(t *testing.T) {
 		t.Fatalf("Len failed, got %v", m.Len())
 	}
 }
+
+func TestCompute(t *testing.T) {
+	m := &rwmap.RWMap[string, int]{}
+	incr := func(old int, loaded bool) (int, rwmap.ComputeOp) {
+		return old + 1, rwmap.StoreOp
+	}
+	if v, ok := m.Compute("a", incr); !ok || v != 1 {
+		t.Fatalf("Compute on an non-existing key failed, got %v, %v", v, ok)
+	}
+	if v, ok := m.Compute("a", incr); !ok || v != 2 {
+		t.Fatalf("Compute on an existing key failed, got %v, %v", v, ok)
+	}
+	if v, ok := m.Compute("a", func(old int, loaded bool) (int, rwmap.ComputeOp) {
+		return 0, rwmap.KeepOp
+	}); !ok || v != 2 {
+		t.Fatalf("Compute with KeepOp changed the value, got %v, %v", v, ok)
+	}
+	if v, ok := m.Compute("b", func(old int, loaded bool) (int, rwmap.ComputeOp) {
+		return 1, rwmap.KeepOp
+	}); ok {
+		t.Fatalf("Compute with KeepOp stored a value, got %v", v)
+	}
+	if _, ok := m.Compute("a", func(old int, loaded bool) (int, rwmap.ComputeOp) {
+		return 0, rwmap.DeleteOp
+	}); ok {
+		t.Fatalf("Compute with DeleteOp did not delete the key")
+	}
+	if v, ok := m.Load("a"); ok {
+		t.Fatalf("Compute with DeleteOp failed, got %v", v)
+	}
+	if m.Len() != 0 {
+		t.Fatalf("Len failed, got %v", m.Len())
+	}
+}
+
+func TestComputeDirtyDelete(t *testing.T) {
+	m := &rwmap.RWMap[int, int]{}
+	// Promote 64 keys to the read map, then add key 1 to the dirty map only.
+	// With 65 dirty keys, the misses below do not promote it.
+	for i := 100; i < 164; i++ {
+		m.Store(i, i)
+	}
+	for i := 0; i < 64; i++ {
+		m.Load(-1)
+	}
+	m.Store(1, 1)
+	deleted := false
+	v, ok := m.Compute(1, func(old int, loaded bool) (int, rwmap.ComputeOp) {
+		// Force a LoadAndDelete between Compute's lookup and its store.
+		if !deleted {
+			deleted = true
+			m.LoadAndDelete(1)
+		}
+		return 7, rwmap.StoreOp
+	})
+	if !ok || v != 7 {
+		t.Fatalf("Compute failed, got %v, %v", v, ok)
+	}
+	if v, ok := m.Load(1); !ok || v != 7 {
+		t.Fatalf("Compute stored into a deleted entry, Load got %v, %v", v, ok)
+	}
+	if m.Len() != 65 || m.SlowLen() != 65 {
+		t.Fatalf("Len failed, got %v, SlowLen %v", m.Len(), m.SlowLen())
+	}
+}
+
+func TestConcurrentCompute(t *testing.T) {
+	const (
+		keys  = 8
+		iters = 1000
+	)
+	m := &rwmap.RWMap[int, int]{}
+	var wg sync.WaitGroup
+	for g := runtime.GOMAXPROCS(0) * 2; g > 0; g-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iters; i++ {
+				m.Compute(i%keys, func(old int, loaded bool) (int, rwmap.ComputeOp) {
+					return old + 1, rwmap.StoreOp
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	m.Range(func(_ int, v int) bool {
+		total += v
+		return true
+	})
+	if want := runtime.GOMAXPROCS(0) * 2 * iters; total != want {
+		t.Fatalf("Compute lost updates, got %v want %v", total, want)
+	}
+	if m.Len() != keys {
+		t.Fatalf("Len failed, got %v want %v", m.Len(), keys)
+	}
+}
+
+func TestLoadOrCompute(t *testing.T) {
+	m := &rwmap.RWMap[int, int]{}
+	var calls int32
+	var wg sync.WaitGroup
+	for g := runtime.GOMAXPROCS(0) * 2; g > 0; g-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, _ := m.LoadOrCompute(1, func() int {
+				atomic.AddInt32(&calls, 1)
+				return 42
+			})
+			if v != 42 {
+				t.Errorf("LoadOrCompute returned unexpected value, got %v want %v", v, 42)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Fatalf("LoadOrCompute called the constructor %v times, want 1", calls)
+	}
+	if v, loaded := m.LoadOrCompute(1, func() int { return 0 }); !loaded || v != 42 {
+		t.Fatalf("LoadOrCompute on an existing key failed, got %v, %v", v, loaded)
+	}
+}
+
+func TestConcurrentLen(t *testing.T) {
+	const (
+		keys  = 64
+		iters = 2000
+	)
+	m := &rwmap.RWMap[int, int]{}
+	for round := 0; round < 8; round++ {
+		var wg sync.WaitGroup
+		for g := runtime.GOMAXPROCS(0) * 2; g > 0; g-- {
+			r := rand.New(rand.NewSource(int64(g + round)))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < iters; i++ {
+					k := r.Intn(keys)
+					switch r.Intn(9) {
+					case 0:
+						m.Store(k, i)
+					case 1:
+						m.Delete(k)
+					case 2:
+						m.LoadAndDelete(k)
+					case 3:
+						m.CompareAndDelete(k, i-1)
+					case 4:
+						m.Swap(k, i)
+					case 5:
+						m.LoadOrStore(k, i)
+					case 6:
+						m.CompareAndSwap(k, i-1, i)
+					case 7:
+						m.Compute(k, func(old int, loaded bool) (int, rwmap.ComputeOp) {
+							if loaded && old%2 == 0 {
+								return 0, rwmap.DeleteOp
+							}
+							return old + 1, rwmap.StoreOp
+						})
+					default:
+						if r.Intn(16) == 0 {
+							m.Clear()
+						} else {
+							m.Load(k)
+						}
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		if l, sl := m.Len(), m.SlowLen(); l != sl {
+			t.Fatalf("round %d: Len() = %v, SlowLen() = %v", round, l, sl)
+		}
+	}
+}
+
+func TestBulk(t *testing.T) {
+	m := &rwmap.RWMap[int, int]{}
+	src := map[int]int{}
+	for i := 0; i < 10; i++ {
+		src[i] = i * 10
+	}
+	m.StoreAll(src)
+	if m.Len() != 10 {
+		t.Fatalf("StoreAll failed, got Len %v", m.Len())
+	}
+	if s := m.Snapshot(); !reflect.DeepEqual(s, src) {
+		t.Fatalf("Snapshot failed, got %v want %v", s, src)
+	}
+	if keys := m.Keys(); len(keys) != 10 {
+		t.Fatalf("Keys failed, got %v", keys)
+	}
+	sum := 0
+	for _, v := range m.Values() {
+		sum += v
+	}
+	if sum != 450 {
+		t.Fatalf("Values failed, got sum %v want %v", sum, 450)
+	}
+
+	if n := m.DeleteFunc(func(k, _ int) bool { return k%2 == 0 }); n != 5 {
+		t.Fatalf("DeleteFunc deleted %v entries, want %v", n, 5)
+	}
+	if m.Len() != 5 || m.SlowLen() != 5 {
+		t.Fatalf("DeleteFunc failed, got Len %v SlowLen %v", m.Len(), m.SlowLen())
+	}
+
+	deleted := m.LoadAndDeleteFunc(func(k, _ int) bool { return k < 5 })
+	if want := map[int]int{1: 10, 3: 30}; !reflect.DeepEqual(deleted, want) {
+		t.Fatalf("LoadAndDeleteFunc failed, got %v want %v", deleted, want)
+	}
+
+	all := m.LoadAndDeleteAll()
+	if want := map[int]int{5: 50, 7: 70, 9: 90}; !reflect.DeepEqual(all, want) {
+		t.Fatalf("LoadAndDeleteAll failed, got %v want %v", all, want)
+	}
+	if m.Len() != 0 || m.SlowLen() != 0 {
+		t.Fatalf("LoadAndDeleteAll failed, got Len %v SlowLen %v", m.Len(), m.SlowLen())
+	}
+}
+
+func TestDeleteFuncDuringStore(t *testing.T) {
+	m := &rwmap.RWMap[int, int]{}
+	for i := 0; i < 100; i++ {
+		m.Store(i, 0)
+	}
+	// Overwriting an entry from inside f means its value is no longer the one
+	// f observed, so it must survive.
+	n := m.DeleteFunc(func(k, _ int) bool {
+		m.Store(k, 1)
+		return true
+	})
+	if n != 0 {
+		t.Fatalf("DeleteFunc deleted %v concurrently stored entries", n)
+	}
+	if m.Len() != 100 {
+		t.Fatalf("Len failed, got %v", m.Len())
+	}
+}
+
+func TestWatch(t *testing.T) {
+	m := &rwmap.RWMap[string, int]{}
+	ctx, cancel := context.WithCancel(context.Background())
+	events := m.Watch(ctx)
+
+	m.Store("a", 1)
+	m.Swap("a", 2)
+	m.LoadOrStore("a", 3)
+	m.CompareAndSwap("a", 2, 4)
+	m.CompareAndSwap("a", 2, 5)
+	m.LoadOrStore("b", 1)
+	m.Delete("c")
+	m.LoadAndDelete("b")
+	m.Store("b", 1)
+	m.CompareAndDelete("b", 1)
+	m.Compute("a", func(old int, loaded bool) (int, rwmap.ComputeOp) {
+		return old + 1, rwmap.StoreOp
+	})
+	m.Clear()
+
+	want := []rwmap.Event[string, int]{
+		{Type: rwmap.PutEvent, Key: "a", NewValue: 1},
+		{Type: rwmap.PutEvent, Key: "a", OldValue: 1, NewValue: 2, Loaded: true},
+		{Type: rwmap.PutEvent, Key: "a", OldValue: 2, NewValue: 4, Loaded: true},
+		{Type: rwmap.PutEvent, Key: "b", NewValue: 1},
+		{Type: rwmap.DeleteEvent, Key: "b", OldValue: 1, Loaded: true},
+		{Type: rwmap.PutEvent, Key: "b", NewValue: 1},
+		{Type: rwmap.DeleteEvent, Key: "b", OldValue: 1, Loaded: true},
+		{Type: rwmap.PutEvent, Key: "a", OldValue: 4, NewValue: 5, Loaded: true},
+		{Type: rwmap.DeleteEvent, Key: "a", OldValue: 5, Loaded: true},
+	}
+	for i, w := range want {
+		if ev := <-events; ev != w {
+			t.Fatalf("event %d: got %+v want %+v", i, ev, w)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+	// Writes after the watcher is gone must not block.
+	m.Store("a", 1)
+}
@@ -0,0 +1,155 @@
+package rwmap
+
+import (
+	"context"
+	"sync"
+)
+
+// EventType is the kind of mutation reported by an Event.
+type EventType int
+
+const (
+	// PutEvent reports that a value was stored for a key.
+	PutEvent EventType = iota
+	// DeleteEvent reports that the entry for a key was deleted.
+	DeleteEvent
+)
+
+func (t EventType) String() string {
+	switch t {
+	case PutEvent:
+		return "put"
+	case DeleteEvent:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a single mutation of a RWMap.
+type Event[K comparable, V any] struct {
+	Type EventType
+	Key  K
+	// OldValue is the value replaced or deleted by the mutation.
+	// It is only meaningful when Loaded is true.
+	OldValue V
+	// NewValue is the value stored by a PutEvent.
+	NewValue V
+	// Loaded reports whether the key held a value before the mutation.
+	Loaded bool
+}
+
+// Watch subscribes to the mutations of the map and returns a channel
+// delivering an Event for every Store, Swap, LoadOrStore that stores,
+// successful CompareAndSwap, Delete, LoadAndDelete, successful CompareAndDelete,
+// Compute, bulk deletion and every entry removed by Clear.
+//
+// Events are buffered without bound, so a slow receiver never blocks writers.
+// Events for one writer are delivered in the order its writes returned; events
+// for concurrent writes to the same key may be delivered in either order, so
+// receivers that need the final state should compare OldValue and NewValue or
+// Load the key.
+//
+// The channel is closed once ctx is done; pending events are dropped.
+func (m *RWMap[K, V]) Watch(ctx context.Context) <-chan Event[K, V] {
+	w := &watcher[K, V]{
+		signal: make(chan struct{}, 1),
+	}
+	ch := make(chan Event[K, V])
+
+	m.watchMu.Lock()
+	m.watchers = append(m.watchers, w)
+	m.nwatchers.Store(int32(len(m.watchers)))
+	m.watchMu.Unlock()
+
+	go func() {
+		defer close(ch)
+		defer m.unwatch(w)
+		w.run(ctx, ch)
+	}()
+	return ch
+}
+
+func (m *RWMap[K, V]) unwatch(w *watcher[K, V]) {
+	m.watchMu.Lock()
+	defer m.watchMu.Unlock()
+	for i, v := range m.watchers {
+		if v == w {
+			m.watchers = append(m.watchers[:i:i], m.watchers[i+1:]...)
+			break
+		}
+	}
+	m.nwatchers.Store(int32(len(m.watchers)))
+}
+
+func (m *RWMap[K, V]) notifyPut(key K, old V, loaded bool, new V) {
+	if m.nwatchers.Load() == 0 {
+		return
+	}
+	m.notify(Event[K, V]{
+		Type:     PutEvent,
+		Key:      key,
+		OldValue: old,
+		NewValue: new,
+		Loaded:   loaded,
+	})
+}
+
+func (m *RWMap[K, V]) notifyDelete(key K, old V) {
+	if m.nwatchers.Load() == 0 {
+		return
+	}
+	m.notify(Event[K, V]{
+		Type:     DeleteEvent,
+		Key:      key,
+		OldValue: old,
+		Loaded:   true,
+	})
+}
+
+func (m *RWMap[K, V]) notify(ev Event[K, V]) {
+	m.watchMu.Lock()
+	defer m.watchMu.Unlock()
+	for _, w := range m.watchers {
+		w.push(ev)
+	}
+}
+
+type watcher[K comparable, V any] struct {
+	mu     sync.Mutex
+	queue  []Event[K, V]
+	signal chan struct{}
+}
+
+func (w *watcher[K, V]) push(ev Event[K, V]) {
+	w.mu.Lock()
+	w.queue = append(w.queue, ev)
+	w.mu.Unlock()
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher[K, V]) run(ctx context.Context, ch chan<- Event[K, V]) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.signal:
+		}
+
+		w.mu.Lock()
+		queue := w.queue
+		w.queue = nil
+		w.mu.Unlock()
+
+		for _, ev := range queue {
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
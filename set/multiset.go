@@ -0,0 +1,236 @@
+package set
+
+import (
+	"github.com/zijiren233/gencontainer/heap"
+	"golang.org/x/exp/maps"
+)
+
+// Multiset is a set that counts how many times each value was added,
+// also known as a bag. The zero Multiset is empty and ready for use.
+type Multiset[T comparable] struct {
+	m   map[T]int
+	len int
+}
+
+// Counted is a value of a Multiset together with its count.
+type Counted[T comparable] struct {
+	Value T
+	Count int
+}
+
+// NewMultiset returns an empty multiset.
+func NewMultiset[T comparable]() *Multiset[T] {
+	return &Multiset[T]{m: make(map[T]int)}
+}
+
+// Len returns the total number of values, counting repetitions.
+func (s *Multiset[T]) Len() int {
+	return s.len
+}
+
+// Distinct returns the number of distinct values.
+func (s *Multiset[T]) Distinct() int {
+	return len(s.m)
+}
+
+func (s *Multiset[T]) IsEmpty() bool {
+	return s.len == 0
+}
+
+// Count returns how many times val is in the multiset.
+func (s *Multiset[T]) Count(val T) int {
+	return s.m[val]
+}
+
+// If value is in the multiset, return true.
+func (s *Multiset[T]) Contain(val T) bool {
+	return s.m[val] > 0
+}
+
+// Add adds n occurrences of val and returns its new count.
+// If n is not positive, the multiset is unchanged.
+func (s *Multiset[T]) Add(val T, n int) int {
+	if n <= 0 {
+		return s.m[val]
+	}
+	if s.m == nil {
+		s.m = make(map[T]int)
+	}
+	s.m[val] += n
+	s.len += n
+	return s.m[val]
+}
+
+// Push adds one occurrence of each value.
+func (s *Multiset[T]) Push(val ...T) *Multiset[T] {
+	for _, v := range val {
+		s.Add(v, 1)
+	}
+	return s
+}
+
+// Remove removes up to n occurrences of val and returns how many were removed.
+// If n is not positive, the multiset is unchanged.
+func (s *Multiset[T]) Remove(val T, n int) int {
+	c := s.m[val]
+	if n <= 0 || c == 0 {
+		return 0
+	}
+	if n >= c {
+		delete(s.m, val)
+		s.len -= c
+		return c
+	}
+	s.m[val] = c - n
+	s.len -= n
+	return n
+}
+
+// RemoveAll removes every occurrence of val and returns how many were removed.
+func (s *Multiset[T]) RemoveAll(val T) int {
+	return s.Remove(val, s.m[val])
+}
+
+func (s *Multiset[T]) Clear() *Multiset[T] {
+	maps.Clear(s.m)
+	s.len = 0
+	return s
+}
+
+func (s *Multiset[T]) Clone() *Multiset[T] {
+	return &Multiset[T]{m: maps.Clone(s.m), len: s.len}
+}
+
+// Range calls f for each distinct value and its count.
+func (s *Multiset[T]) Range(f func(val T, count int) (Continue bool)) {
+	for k, c := range s.m {
+		if !f(k, c) {
+			break
+		}
+	}
+}
+
+// Set returns the distinct values as a Set.
+func (s *Multiset[T]) Set() Set[T] {
+	set := make(Set[T], len(s.m))
+	for k := range s.m {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+// Slice returns every value, repeated as many times as it was added.
+func (s *Multiset[T]) Slice() []T {
+	d := make([]T, 0, s.len)
+	for k, c := range s.m {
+		for i := 0; i < c; i++ {
+			d = append(d, k)
+		}
+	}
+	return d
+}
+
+func (s *Multiset[T]) Equal(set *Multiset[T]) bool {
+	return s.len == set.len && maps.Equal(s.m, set.m)
+}
+
+// If every value of s occurs in set at least as many times, return true.
+func (s *Multiset[T]) IsSubSet(set *Multiset[T]) bool {
+	if s.len > set.len {
+		return false
+	}
+	for k, c := range s.m {
+		if set.m[k] < c {
+			return false
+		}
+	}
+	return true
+}
+
+// MostCommon returns the k values with the highest counts, most common first.
+// Values with equal counts are returned in an indeterminate order.
+func (s *Multiset[T]) MostCommon(k int) []Counted[T] {
+	if k <= 0 {
+		return nil
+	}
+	h := make(countHeap[T], 0, min(k, len(s.m)))
+	for v, c := range s.m {
+		if h.Len() < k {
+			heap.Push[Counted[T]](&h, Counted[T]{Value: v, Count: c})
+		} else if c > h[0].Count {
+			h[0] = Counted[T]{Value: v, Count: c}
+			heap.Fix[Counted[T]](&h, 0)
+		}
+	}
+	common := make([]Counted[T], h.Len())
+	for i := len(common) - 1; i >= 0; i-- {
+		common[i] = heap.Pop[Counted[T]](&h)
+	}
+	return common
+}
+
+// countHeap is a min-heap of counts, so the least common of the values kept
+// so far is at the root.
+type countHeap[T comparable] []Counted[T]
+
+func (h countHeap[T]) Len() int           { return len(h) }
+func (h countHeap[T]) Less(i, j int) bool { return h[i].Count < h[j].Count }
+func (h countHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *countHeap[T]) Push(x Counted[T]) {
+	*h = append(*h, x)
+}
+
+func (h *countHeap[T]) Pop() Counted[T] {
+	n := len(*h)
+	x := (*h)[n-1]
+	*h = (*h)[:n-1]
+	return x
+}
+
+// Union returns a multiset holding each value with the highest of its counts.
+func (s *Multiset[T]) Union(set ...*Multiset[T]) *Multiset[T] {
+	union := s.Clone()
+	for _, v := range set {
+		for k, c := range v.m {
+			if c > union.m[k] {
+				union.Add(k, c-union.m[k])
+			}
+		}
+	}
+	return union
+}
+
+// Intersection returns a multiset holding each value with the lowest of its counts.
+func (s *Multiset[T]) Intersection(set *Multiset[T]) *Multiset[T] {
+	small, large := s, set
+	if len(small.m) > len(large.m) {
+		small, large = large, small
+	}
+	intersection := NewMultiset[T]()
+	for k, c := range small.m {
+		intersection.Add(k, min(c, large.m[k]))
+	}
+	return intersection
+}
+
+// Sum returns a multiset holding each value with the sum of its counts.
+func (s *Multiset[T]) Sum(set ...*Multiset[T]) *Multiset[T] {
+	sum := s.Clone()
+	for _, v := range set {
+		for k, c := range v.m {
+			sum.Add(k, c)
+		}
+	}
+	return sum
+}
+
+// Difference returns a multiset holding each value of s with its count
+// reduced by its count in set, dropping values that reach zero.
+func (s *Multiset[T]) Difference(set *Multiset[T]) *Multiset[T] {
+	diff := s.Clone()
+	for k, c := range set.m {
+		diff.Remove(k, c)
+	}
+	return diff
+}
@@ -2,14 +2,13 @@
 package set
 
 import (
-	"golang.org/x/exp/constraints"
 	"golang.org/x/exp/maps"
 )
 
-type Set[T constraints.Ordered] map[T]struct{}
+type Set[T comparable] map[T]struct{}
 
 // Set is a container that stores unique values.
-func New[T constraints.Ordered]() Set[T] {
+func New[T comparable]() Set[T] {
 	return make(Set[T])
 }
 
@@ -145,11 +144,13 @@ func (s Set[T]) SymmetricDifference(set Set[T]) (diff Set[T]) {
 	return
 }
 
-// Contains all elements in s and set.
-func (s Set[T]) Intersection(set Set[T]) (intersection Set[T]) {
+// Contains all elements in s and every set.
+// It iterates over the smallest operand.
+func (s Set[T]) Intersection(set ...Set[T]) (intersection Set[T]) {
+	smallest, others := smallestOf(s, set)
 	intersection = New[T]()
-	s.Range(func(val T) bool {
-		if set.Contain(val) {
+	smallest.Range(func(val T) bool {
+		if containAll(others, val) {
 			intersection.Insert(val)
 		}
 		return true
@@ -157,6 +158,70 @@ func (s Set[T]) Intersection(set Set[T]) (intersection Set[T]) {
 	return
 }
 
+// IntersectionLen returns the number of elements in both s and set
+// without building the intersection.
+func (s Set[T]) IntersectionLen(set Set[T]) (n int) {
+	small, large := s, set
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	for k := range small {
+		if large.Contain(k) {
+			n++
+		}
+	}
+	return
+}
+
+// If s and set have no element in common, return true.
+func (s Set[T]) IsDisjoint(set Set[T]) bool {
+	small, large := s, set
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	for k := range small {
+		if large.Contain(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// If s is a subset of set and not equal to it, return true.
+func (s Set[T]) IsProperSubset(set Set[T]) bool {
+	return s.Len() < set.Len() && s.IsSubSet(set)
+}
+
+// smallestOf returns the smallest of s and sets, and all the others.
+func smallestOf[T comparable](s Set[T], sets []Set[T]) (smallest Set[T], others []Set[T]) {
+	smallest = s
+	idx := -1
+	for i, v := range sets {
+		if v.Len() < smallest.Len() {
+			smallest, idx = v, i
+		}
+	}
+	others = make([]Set[T], 0, len(sets))
+	if idx != -1 {
+		others = append(others, s)
+	}
+	for i, v := range sets {
+		if i != idx {
+			others = append(others, v)
+		}
+	}
+	return
+}
+
+func containAll[T comparable](sets []Set[T], val T) bool {
+	for _, v := range sets {
+		if !v.Contain(val) {
+			return false
+		}
+	}
+	return true
+}
+
 // Union all sets.
 func (s Set[T]) Union(set ...Set[T]) (union Set[T]) {
 	union = New[T]()
@@ -172,3 +237,48 @@ func (s Set[T]) Union(set ...Set[T]) (union Set[T]) {
 	}
 	return
 }
+
+// UnionWith inserts all elements of every set into s.
+func (s Set[T]) UnionWith(set ...Set[T]) Set[T] {
+	for _, v := range set {
+		maps.Copy(s, v)
+	}
+	return s
+}
+
+// IntersectWith removes from s all elements not in every set.
+func (s Set[T]) IntersectWith(set ...Set[T]) Set[T] {
+	for k := range s {
+		if !containAll(set, k) {
+			delete(s, k)
+		}
+	}
+	return s
+}
+
+// DifferenceWith removes from s all elements in set.
+// It iterates over the smaller operand.
+func (s Set[T]) DifferenceWith(set Set[T]) Set[T] {
+	if s.Len() < set.Len() {
+		for k := range s {
+			if set.Contain(k) {
+				delete(s, k)
+			}
+		}
+		return s
+	}
+	for k := range set {
+		delete(s, k)
+	}
+	return s
+}
+
+// SymmetricDifferenceWith leaves in s the elements in s or set but not both.
+func (s Set[T]) SymmetricDifferenceWith(set Set[T]) Set[T] {
+	for k := range set {
+		if !s.Remove(k) {
+			s[k] = struct{}{}
+		}
+	}
+	return s
+}
@@ -1,7 +1,10 @@
 package set
 
 import (
+	"sync"
 	"testing"
+
+	"golang.org/x/exp/slices"
 )
 
 func TestNew(t *testing.T) {
@@ -176,3 +179,256 @@ func TestUnion(t *testing.T) {
 		t.Errorf("s3.Contain(2) = %t, want %t", s3.Contain(2), true)
 	}
 }
+
+func TestSyncSet(t *testing.T) {
+	s := NewSync[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.Insert(i)
+				if i%2 == 1 {
+					s.Remove(i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	s.Push(1)
+	if s.Len() != 51 {
+		t.Errorf("s.Len() = %d, want %d", s.Len(), 51)
+	}
+	if !s.Contain(1) || s.Contain(3) {
+		t.Errorf("s.Contain(1) = %t, s.Contain(3) = %t, want true, false", s.Contain(1), s.Contain(3))
+	}
+	if l := len(s.Slice()); l != 51 {
+		t.Errorf("len(s.Slice()) = %d, want %d", l, 51)
+	}
+}
+
+func TestSyncSetAlgebra(t *testing.T) {
+	s1 := NewSync[int]()
+	s2 := NewSync[int]()
+	for i := 0; i < 10; i++ {
+		s1.Insert(i)
+		s2.Insert(i)
+	}
+	s1.Remove(0)
+	s2.Remove(2)
+	if u := s1.Union(s2); u.Len() != 10 {
+		t.Errorf("s1.Union(s2).Len() = %d, want %d", u.Len(), 10)
+	}
+	if i := s1.Intersection(s2); i.Len() != 8 || i.Contain(0) || i.Contain(2) {
+		t.Errorf("s1.Intersection(s2) = %v", i.Slice())
+	}
+	if d := s1.Difference(s2); d.Len() != 1 || !d.Contain(2) {
+		t.Errorf("s1.Difference(s2) = %v", d.Slice())
+	}
+	if d := s1.SymmetricDifference(s2); d.Len() != 2 || !d.Contain(0) || !d.Contain(2) {
+		t.Errorf("s1.SymmetricDifference(s2) = %v", d.Slice())
+	}
+	if !s1.Snapshot().Equal(New[int]().Push(1, 2, 3, 4, 5, 6, 7, 8, 9)) {
+		t.Errorf("s1.Snapshot() = %v", s1.Snapshot().Slice())
+	}
+}
+
+type point struct{ x, y int }
+
+func TestComparable(t *testing.T) {
+	s := New[point]().Push(point{1, 2}, point{1, 2}, point{2, 1})
+	if s.Len() != 2 {
+		t.Errorf("s.Len() = %d, want %d", s.Len(), 2)
+	}
+	if !s.Contain(point{2, 1}) {
+		t.Errorf("s.Contain(point{2, 1}) = %t, want %t", s.Contain(point{2, 1}), true)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	s := NewSorted[int]().Push(5, 1, 9, 3, 7, 3)
+	if !slices.Equal(s.Slice(), []int{1, 3, 5, 7, 9}) {
+		t.Errorf("s.Slice() = %v, want %v", s.Slice(), []int{1, 3, 5, 7, 9})
+	}
+	if v, ok := s.Min(); !ok || v != 1 {
+		t.Errorf("s.Min() = %d, %t, want %d, %t", v, ok, 1, true)
+	}
+	if v, ok := s.Max(); !ok || v != 9 {
+		t.Errorf("s.Max() = %d, %t, want %d, %t", v, ok, 9, true)
+	}
+	if v, ok := s.Floor(4); !ok || v != 3 {
+		t.Errorf("s.Floor(4) = %d, %t, want %d, %t", v, ok, 3, true)
+	}
+	if v, ok := s.Floor(5); !ok || v != 5 {
+		t.Errorf("s.Floor(5) = %d, %t, want %d, %t", v, ok, 5, true)
+	}
+	if _, ok := s.Floor(0); ok {
+		t.Errorf("s.Floor(0) found a value, want none")
+	}
+	if v, ok := s.Ceiling(6); !ok || v != 7 {
+		t.Errorf("s.Ceiling(6) = %d, %t, want %d, %t", v, ok, 7, true)
+	}
+	if _, ok := s.Ceiling(10); ok {
+		t.Errorf("s.Ceiling(10) found a value, want none")
+	}
+
+	var got []int
+	s.RangeFrom(3, 9, func(val int) bool {
+		got = append(got, val)
+		return true
+	})
+	if !slices.Equal(got, []int{3, 5, 7}) {
+		t.Errorf("s.RangeFrom(3, 9) = %v, want %v", got, []int{3, 5, 7})
+	}
+
+	if !s.Remove(5) || s.Remove(5) || s.Contain(5) {
+		t.Errorf("s.Remove(5) failed")
+	}
+	if !SortedFrom(s.Set()).Equal(s) {
+		t.Errorf("SortedFrom(s.Set()) = %v, want %v", SortedFrom(s.Set()).Slice(), s.Slice())
+	}
+	if _, ok := s.Clear().Min(); ok {
+		t.Errorf("s.Clear().Min() found a value, want none")
+	}
+}
+
+func TestMultiIntersection(t *testing.T) {
+	s1 := New[int]().Push(1, 2, 3, 4, 5)
+	s2 := New[int]().Push(2, 3, 4)
+	s3 := New[int]().Push(3, 4, 5, 6)
+	s := s1.Intersection(s2, s3)
+	if !s.Equal(New[int]().Push(3, 4)) {
+		t.Errorf("s1.Intersection(s2, s3) = %v, want %v", s.Slice(), []int{3, 4})
+	}
+	if !s1.Intersection().Equal(s1) {
+		t.Errorf("s1.Intersection() = %v, want %v", s1.Intersection().Slice(), s1.Slice())
+	}
+	if n := s1.IntersectionLen(s3); n != 3 {
+		t.Errorf("s1.IntersectionLen(s3) = %d, want %d", n, 3)
+	}
+}
+
+func TestInPlace(t *testing.T) {
+	s := New[int]().Push(1, 2, 3)
+	s.UnionWith(New[int]().Push(3, 4), New[int]().Push(5))
+	if !s.Equal(New[int]().Push(1, 2, 3, 4, 5)) {
+		t.Errorf("UnionWith = %v", s.Slice())
+	}
+	s.IntersectWith(New[int]().Push(1, 2, 3, 4), New[int]().Push(2, 3, 4, 9))
+	if !s.Equal(New[int]().Push(2, 3, 4)) {
+		t.Errorf("IntersectWith = %v", s.Slice())
+	}
+	s.DifferenceWith(New[int]().Push(4, 5, 6, 7, 8))
+	if !s.Equal(New[int]().Push(2, 3)) {
+		t.Errorf("DifferenceWith = %v", s.Slice())
+	}
+	s.DifferenceWith(New[int]().Push(2))
+	if !s.Equal(New[int]().Push(3)) {
+		t.Errorf("DifferenceWith = %v", s.Slice())
+	}
+	s.SymmetricDifferenceWith(New[int]().Push(3, 4))
+	if !s.Equal(New[int]().Push(4)) {
+		t.Errorf("SymmetricDifferenceWith = %v", s.Slice())
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	s1 := New[int]().Push(1, 2)
+	s2 := New[int]().Push(1, 2, 3)
+	s3 := New[int]().Push(4, 5)
+	if !s1.IsProperSubset(s2) {
+		t.Errorf("s1.IsProperSubset(s2) = %t, want %t", s1.IsProperSubset(s2), true)
+	}
+	if s1.IsProperSubset(s1.Clone()) {
+		t.Errorf("s1.IsProperSubset(s1) = %t, want %t", true, false)
+	}
+	if !s1.IsDisjoint(s3) || s1.IsDisjoint(s2) {
+		t.Errorf("IsDisjoint failed")
+	}
+}
+
+func TestMultiset(t *testing.T) {
+	s := NewMultiset[string]()
+	s.Add("a", 3)
+	s.Add("b", 1)
+	s.Push("b", "c")
+	s.Add("d", 0)
+	if s.Len() != 6 || s.Distinct() != 3 {
+		t.Errorf("s.Len() = %d, s.Distinct() = %d, want %d, %d", s.Len(), s.Distinct(), 6, 3)
+	}
+	if s.Count("a") != 3 || s.Count("d") != 0 {
+		t.Errorf("s.Count(a) = %d, s.Count(d) = %d, want %d, %d", s.Count("a"), s.Count("d"), 3, 0)
+	}
+	if n := s.Remove("a", 2); n != 2 || s.Count("a") != 1 {
+		t.Errorf("s.Remove(a, 2) = %d, s.Count(a) = %d, want %d, %d", n, s.Count("a"), 2, 1)
+	}
+	if n := s.Remove("c", 5); n != 1 || s.Contain("c") {
+		t.Errorf("s.Remove(c, 5) = %d, want %d", n, 1)
+	}
+	if s.Len() != 3 || len(s.Slice()) != 3 {
+		t.Errorf("s.Len() = %d, want %d", s.Len(), 3)
+	}
+	if !s.Set().Equal(New[string]().Push("a", "b")) {
+		t.Errorf("s.Set() = %v", s.Set().Slice())
+	}
+
+	var z Multiset[int]
+	if z.Count(1) != 0 || z.Remove(1, 1) != 0 || z.Clone().Len() != 0 {
+		t.Errorf("zero multiset is not empty")
+	}
+	if n := z.Add(1, 2); n != 2 || z.Len() != 2 {
+		t.Errorf("z.Add(1, 2) = %d, z.Len() = %d, want %d, %d", n, z.Len(), 2, 2)
+	}
+}
+
+func TestMostCommon(t *testing.T) {
+	s := NewMultiset[int]()
+	for i := 1; i <= 10; i++ {
+		s.Add(i, i)
+	}
+	common := s.MostCommon(3)
+	want := []Counted[int]{{10, 10}, {9, 9}, {8, 8}}
+	if !slices.Equal(common, want) {
+		t.Errorf("s.MostCommon(3) = %v, want %v", common, want)
+	}
+	if l := len(s.MostCommon(20)); l != 10 {
+		t.Errorf("len(s.MostCommon(20)) = %d, want %d", l, 10)
+	}
+	if s.MostCommon(0) != nil {
+		t.Errorf("s.MostCommon(0) = %v, want nil", s.MostCommon(0))
+	}
+}
+
+func TestMultisetAlgebra(t *testing.T) {
+	s1 := NewMultiset[string]()
+	s1.Add("a", 3)
+	s1.Add("b", 1)
+	s2 := NewMultiset[string]()
+	s2.Add("a", 1)
+	s2.Add("b", 2)
+	s2.Add("c", 1)
+
+	u := s1.Union(s2)
+	if u.Count("a") != 3 || u.Count("b") != 2 || u.Count("c") != 1 || u.Len() != 6 {
+		t.Errorf("Union failed, got len %d", u.Len())
+	}
+	i := s1.Intersection(s2)
+	if i.Count("a") != 1 || i.Count("b") != 1 || i.Contain("c") || i.Len() != 2 {
+		t.Errorf("Intersection failed, got len %d", i.Len())
+	}
+	sum := s1.Sum(s2)
+	if sum.Count("a") != 4 || sum.Count("b") != 3 || sum.Len() != 8 {
+		t.Errorf("Sum failed, got len %d", sum.Len())
+	}
+	d := s1.Difference(s2)
+	if d.Count("a") != 2 || d.Contain("b") || d.Len() != 2 {
+		t.Errorf("Difference failed, got len %d", d.Len())
+	}
+	if !i.IsSubSet(s1) || !i.IsSubSet(s2) || s1.IsSubSet(s2) {
+		t.Errorf("IsSubSet failed")
+	}
+	if !s1.Equal(s1.Clone()) || s1.Equal(s2) {
+		t.Errorf("Equal failed")
+	}
+}
@@ -0,0 +1,160 @@
+package set
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// Sorted is a set that keeps its values in ascending order.
+//
+// It is backed by a sorted slice: lookups and ordered queries are O(log n),
+// while Insert and Remove are O(n) because they shift the tail of the slice.
+// Use Set when ordering is not needed.
+type Sorted[T constraints.Ordered] struct {
+	d []T
+}
+
+// NewSorted returns an empty sorted set.
+func NewSorted[T constraints.Ordered]() *Sorted[T] {
+	return &Sorted[T]{}
+}
+
+// SortedFrom returns a sorted set holding the values of s.
+func SortedFrom[T constraints.Ordered](s Set[T]) *Sorted[T] {
+	return &Sorted[T]{d: SortedSlice(s)}
+}
+
+// SortedSlice returns the values of s in ascending order.
+func SortedSlice[T constraints.Ordered](s Set[T]) []T {
+	d := s.Slice()
+	slices.Sort(d)
+	return d
+}
+
+// Len returns the length of the set.
+func (s *Sorted[T]) Len() int {
+	return len(s.d)
+}
+
+func (s *Sorted[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Slice returns the values of the set in ascending order.
+func (s *Sorted[T]) Slice() []T {
+	return slices.Clone(s.d)
+}
+
+// Set returns the values of the set as a hash set.
+func (s *Sorted[T]) Set() Set[T] {
+	return New[T]().Push(s.d...)
+}
+
+func (s *Sorted[T]) Clone() *Sorted[T] {
+	return &Sorted[T]{d: slices.Clone(s.d)}
+}
+
+func (s *Sorted[T]) Push(val ...T) *Sorted[T] {
+	for _, v := range val {
+		s.Insert(v)
+	}
+	return s
+}
+
+// If value is not in the set, insert it and return true.
+// If value is in the set, return false.
+func (s *Sorted[T]) Insert(val T) bool {
+	i, ok := slices.BinarySearch(s.d, val)
+	if ok {
+		return false
+	}
+	s.d = slices.Insert(s.d, i, val)
+	return true
+}
+
+// If value is in the set, return true.
+func (s *Sorted[T]) Contain(val T) bool {
+	_, ok := slices.BinarySearch(s.d, val)
+	return ok
+}
+
+// Remove removes the value from the set.
+// If value is in the set, return true.
+// If value is not in the set, return false.
+func (s *Sorted[T]) Remove(val T) bool {
+	i, ok := slices.BinarySearch(s.d, val)
+	if !ok {
+		return false
+	}
+	s.d = slices.Delete(s.d, i, i+1)
+	return true
+}
+
+func (s *Sorted[T]) Clear() *Sorted[T] {
+	s.d = s.d[:0]
+	return s
+}
+
+// Min returns the smallest value in the set.
+// If the set is empty, ok is false.
+func (s *Sorted[T]) Min() (val T, ok bool) {
+	if len(s.d) == 0 {
+		return
+	}
+	return s.d[0], true
+}
+
+// Max returns the largest value in the set.
+// If the set is empty, ok is false.
+func (s *Sorted[T]) Max() (val T, ok bool) {
+	if len(s.d) == 0 {
+		return
+	}
+	return s.d[len(s.d)-1], true
+}
+
+// Floor returns the largest value in the set less than or equal to val.
+// If there is no such value, ok is false.
+func (s *Sorted[T]) Floor(val T) (floor T, ok bool) {
+	i, found := slices.BinarySearch(s.d, val)
+	if found {
+		return s.d[i], true
+	}
+	if i == 0 {
+		return
+	}
+	return s.d[i-1], true
+}
+
+// Ceiling returns the smallest value in the set greater than or equal to val.
+// If there is no such value, ok is false.
+func (s *Sorted[T]) Ceiling(val T) (ceiling T, ok bool) {
+	i, _ := slices.BinarySearch(s.d, val)
+	if i == len(s.d) {
+		return
+	}
+	return s.d[i], true
+}
+
+// Range calls f for each value in ascending order.
+func (s *Sorted[T]) Range(f func(val T) (Continue bool)) {
+	for _, v := range s.d {
+		if !f(v) {
+			break
+		}
+	}
+}
+
+// RangeFrom calls f in ascending order for each value v with lo <= v < hi.
+func (s *Sorted[T]) RangeFrom(lo, hi T, f func(val T) (Continue bool)) {
+	i, _ := slices.BinarySearch(s.d, lo)
+	for ; i < len(s.d) && s.d[i] < hi; i++ {
+		if !f(s.d[i]) {
+			break
+		}
+	}
+}
+
+func (s *Sorted[T]) Equal(set *Sorted[T]) bool {
+	return slices.Equal(s.d, set.d)
+}
@@ -0,0 +1,126 @@
+package set
+
+import (
+	"github.com/zijiren233/gencontainer/rwmap"
+)
+
+// SyncSet is a set that is safe for concurrent use by multiple goroutines.
+// It is backed by a rwmap.RWMap, so it shares its performance characteristics.
+//
+// Set algebra on a SyncSet works on a snapshot of its members and returns a
+// plain Set.
+//
+// The zero SyncSet is empty and ready for use. A SyncSet must not be copied after first use.
+type SyncSet[T comparable] struct {
+	m rwmap.RWMap[T, struct{}]
+}
+
+// NewSync returns an empty SyncSet.
+func NewSync[T comparable]() *SyncSet[T] {
+	return &SyncSet[T]{}
+}
+
+// Len returns the length of the set.
+func (s *SyncSet[T]) Len() int {
+	return int(s.m.Len())
+}
+
+func (s *SyncSet[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+func (s *SyncSet[T]) Push(val ...T) *SyncSet[T] {
+	for _, v := range val {
+		s.m.Store(v, struct{}{})
+	}
+	return s
+}
+
+// If value is not in the set, insert it and return true.
+// If value is in the set, return false.
+func (s *SyncSet[T]) Insert(val T) bool {
+	_, loaded := s.m.LoadOrStore(val, struct{}{})
+	return !loaded
+}
+
+// If value is in the set, return true.
+func (s *SyncSet[T]) Contain(val T) bool {
+	_, ok := s.m.Load(val)
+	return ok
+}
+
+// Remove removes the value from the set.
+// If value is in the set, return true.
+// If value is not in the set, return false.
+func (s *SyncSet[T]) Remove(val T) bool {
+	_, loaded := s.m.LoadAndDelete(val)
+	return loaded
+}
+
+func (s *SyncSet[T]) Clear() *SyncSet[T] {
+	s.m.Clear()
+	return s
+}
+
+// Range calls f for each value in the set. Like rwmap.RWMap.Range it does not
+// block other methods, and f may modify the set.
+func (s *SyncSet[T]) Range(f func(val T) (Continue bool)) {
+	s.m.Range(func(key T, _ struct{}) bool {
+		return f(key)
+	})
+}
+
+func (s *SyncSet[T]) Slice() []T {
+	return s.m.Keys()
+}
+
+// Snapshot returns the members of the set as a plain Set.
+func (s *SyncSet[T]) Snapshot() Set[T] {
+	set := make(Set[T], s.Len())
+	s.Range(func(val T) bool {
+		set[val] = struct{}{}
+		return true
+	})
+	return set
+}
+
+// Contains all elements in the original s but not in the set
+func (s *SyncSet[T]) Difference(set *SyncSet[T]) Set[T] {
+	diff := New[T]()
+	s.Range(func(val T) bool {
+		if !set.Contain(val) {
+			diff.Insert(val)
+		}
+		return true
+	})
+	return diff
+}
+
+// Contains all elements in s or set but not both.
+func (s *SyncSet[T]) SymmetricDifference(set *SyncSet[T]) Set[T] {
+	return s.Snapshot().SymmetricDifference(set.Snapshot())
+}
+
+// Contains all elements in s and set.
+func (s *SyncSet[T]) Intersection(set *SyncSet[T]) Set[T] {
+	intersection := New[T]()
+	s.Range(func(val T) bool {
+		if set.Contain(val) {
+			intersection.Insert(val)
+		}
+		return true
+	})
+	return intersection
+}
+
+// Union all sets.
+func (s *SyncSet[T]) Union(set ...*SyncSet[T]) Set[T] {
+	union := s.Snapshot()
+	for _, v := range set {
+		v.Range(func(val T) bool {
+			union.Insert(val)
+			return true
+		})
+	}
+	return union
+}
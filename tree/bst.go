@@ -0,0 +1,328 @@
+package tree
+
+import (
+	"cmp"
+)
+
+// BST is a binary search tree of distinct values ordered by a comparator,
+// kept balanced as an AVL tree. Find, Insert, Delete, Rank and Select take
+// O(log n) time.
+//
+// The tree is made of Nodes linked through Parent, Left and Right. Nodes
+// returned by its methods stay valid until they are deleted, but must not
+// be relinked or have their Val changed directly.
+type BST[T any] struct {
+	root *Node[T]
+	cmp  func(a, b T) int
+}
+
+// NewBST returns an empty tree ordered by cmp, which returns a negative
+// number if a < b, zero if a == b and a positive number if a > b.
+func NewBST[T any](cmp func(a, b T) int) *BST[T] {
+	return &BST[T]{cmp: cmp}
+}
+
+// NewOrderedBST returns an empty tree in ascending order.
+func NewOrderedBST[T cmp.Ordered]() *BST[T] {
+	return NewBST[T](cmp.Compare[T])
+}
+
+func height[T any](n *Node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func size[T any](n *Node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (t *BST[T]) Root() *Node[T] {
+	return t.root
+}
+
+func (t *BST[T]) Len() int {
+	return size(t.root)
+}
+
+// Find returns the node holding v, or nil if v is not in the tree.
+func (t *BST[T]) Find(v T) *Node[T] {
+	n := t.root
+	for n != nil {
+		switch c := t.cmp(v, n.Val); {
+		case c < 0:
+			n = n.Left
+		case c > 0:
+			n = n.Right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Contain reports whether v is in the tree.
+func (t *BST[T]) Contain(v T) bool {
+	return t.Find(v) != nil
+}
+
+// Insert adds v to the tree and returns its node. If an equal value is
+// already in the tree, Insert returns its node and false.
+func (t *BST[T]) Insert(v T) (n *Node[T], inserted bool) {
+	var parent *Node[T]
+	c := 0
+	for n = t.root; n != nil; {
+		parent = n
+		c = t.cmp(v, n.Val)
+		switch {
+		case c < 0:
+			n = n.Left
+		case c > 0:
+			n = n.Right
+		default:
+			return n, false
+		}
+	}
+	n = &Node[T]{Val: v, Parent: parent, height: 1, size: 1}
+	switch {
+	case parent == nil:
+		t.root = n
+	case c < 0:
+		parent.Left = n
+	default:
+		parent.Right = n
+	}
+	t.rebalance(parent)
+	return n, true
+}
+
+// Delete removes v from the tree. It reports whether v was in the tree.
+func (t *BST[T]) Delete(v T) bool {
+	n := t.Find(v)
+	if n == nil {
+		return false
+	}
+	t.DeleteNode(n)
+	return true
+}
+
+// DeleteNode removes n, which must be a node of the tree.
+func (t *BST[T]) DeleteNode(n *Node[T]) {
+	var start *Node[T]
+	switch {
+	case n.Left == nil:
+		start = n.Parent
+		t.replace(n, n.Right)
+	case n.Right == nil:
+		start = n.Parent
+		t.replace(n, n.Left)
+	default:
+		// Move the successor, which has no left child, into n's place.
+		s := n.Right
+		for s.Left != nil {
+			s = s.Left
+		}
+		if s.Parent == n {
+			start = s
+		} else {
+			start = s.Parent
+			t.replace(s, s.Right)
+			s.Right = n.Right
+			s.Right.Parent = s
+		}
+		t.replace(n, s)
+		s.Left = n.Left
+		s.Left.Parent = s
+	}
+	n.Parent, n.Left, n.Right = nil, nil, nil
+	n.height, n.size = 0, 0
+	t.rebalance(start)
+}
+
+// Clear removes all nodes.
+func (t *BST[T]) Clear() {
+	t.root = nil
+}
+
+// Min returns the node holding the smallest value, or nil if the tree is
+// empty.
+func (t *BST[T]) Min() *Node[T] {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+	for n.Left != nil {
+		n = n.Left
+	}
+	return n
+}
+
+// Max returns the node holding the largest value, or nil if the tree is
+// empty.
+func (t *BST[T]) Max() *Node[T] {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+	for n.Right != nil {
+		n = n.Right
+	}
+	return n
+}
+
+// Successor returns the node holding the smallest value greater than v,
+// or nil if there is none. v need not be in the tree.
+func (t *BST[T]) Successor(v T) (res *Node[T]) {
+	for n := t.root; n != nil; {
+		if t.cmp(v, n.Val) < 0 {
+			res = n
+			n = n.Left
+		} else {
+			n = n.Right
+		}
+	}
+	return
+}
+
+// Predecessor returns the node holding the largest value less than v,
+// or nil if there is none. v need not be in the tree.
+func (t *BST[T]) Predecessor(v T) (res *Node[T]) {
+	for n := t.root; n != nil; {
+		if t.cmp(v, n.Val) > 0 {
+			res = n
+			n = n.Right
+		} else {
+			n = n.Left
+		}
+	}
+	return
+}
+
+// Rank returns the number of values in the tree less than v.
+// v need not be in the tree.
+func (t *BST[T]) Rank(v T) int {
+	r := 0
+	for n := t.root; n != nil; {
+		if t.cmp(v, n.Val) <= 0 {
+			n = n.Left
+		} else {
+			r += size(n.Left) + 1
+			n = n.Right
+		}
+	}
+	return r
+}
+
+// Select returns the node holding the i-th smallest value, counting from 0,
+// or nil if i is out of range.
+func (t *BST[T]) Select(i int) *Node[T] {
+	if i < 0 || i >= t.Len() {
+		return nil
+	}
+	n := t.root
+	for {
+		switch l := size(n.Left); {
+		case i < l:
+			n = n.Left
+		case i > l:
+			i -= l + 1
+			n = n.Right
+		default:
+			return n
+		}
+	}
+}
+
+// Range calls f for each value in ascending order.
+// If f returns false, range stops the iteration.
+func (t *BST[T]) Range(f func(v T) bool) {
+	for n := t.Min(); n != nil; n = n.Successor() {
+		if !f(n.Val) {
+			return
+		}
+	}
+}
+
+// Slice returns the values in ascending order.
+func (t *BST[T]) Slice() []T {
+	s := make([]T, 0, t.Len())
+	t.Range(func(v T) bool {
+		s = append(s, v)
+		return true
+	})
+	return s
+}
+
+// replace puts v in u's place under u's parent. v may be nil.
+func (t *BST[T]) replace(u, v *Node[T]) {
+	switch {
+	case u.Parent == nil:
+		t.root = v
+	case u.Parent.Left == u:
+		u.Parent.Left = v
+	default:
+		u.Parent.Right = v
+	}
+	if v != nil {
+		v.Parent = u.Parent
+	}
+}
+
+func update[T any](n *Node[T]) {
+	n.height = max(height(n.Left), height(n.Right)) + 1
+	n.size = size(n.Left) + size(n.Right) + 1
+}
+
+// rotateLeft lifts n's right child into n's place and returns it.
+func (t *BST[T]) rotateLeft(n *Node[T]) *Node[T] {
+	r := n.Right
+	n.Right = r.Left
+	if r.Left != nil {
+		r.Left.Parent = n
+	}
+	t.replace(n, r)
+	r.Left = n
+	n.Parent = r
+	update(n)
+	update(r)
+	return r
+}
+
+// rotateRight lifts n's left child into n's place and returns it.
+func (t *BST[T]) rotateRight(n *Node[T]) *Node[T] {
+	l := n.Left
+	n.Left = l.Right
+	if l.Right != nil {
+		l.Right.Parent = n
+	}
+	t.replace(n, l)
+	l.Right = n
+	n.Parent = l
+	update(n)
+	update(l)
+	return l
+}
+
+// rebalance updates n and its ancestors after a change below n, rotating
+// where the heights of two subtrees differ by more than one.
+func (t *BST[T]) rebalance(n *Node[T]) {
+	for ; n != nil; n = n.Parent {
+		update(n)
+		switch b := height(n.Left) - height(n.Right); {
+		case b > 1:
+			if height(n.Left.Left) < height(n.Left.Right) {
+				t.rotateLeft(n.Left)
+			}
+			n = t.rotateRight(n)
+		case b < -1:
+			if height(n.Right.Right) < height(n.Right.Left) {
+				t.rotateRight(n.Right)
+			}
+			n = t.rotateLeft(n)
+		}
+	}
+}
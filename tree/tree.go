@@ -1,6 +1,8 @@
 package tree
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,6 +12,10 @@ import (
 type Node[T any] struct {
 	Val                 T
 	Parent, Left, Right *Node[T]
+
+	// height and size are maintained by BST for balancing and order
+	// statistics, and are zero in trees built by other means.
+	height, size int
 }
 
 func NewNode[T any](val T) *Node[T] {
@@ -40,25 +46,184 @@ func BuildTree[T any](val []T) (root *Node[T]) {
 	return
 }
 
+// BuildTreeNullable builds a tree from its level order, in which a nil
+// element marks a missing child, as in the LeetCode array [1,null,2,3].
+// Missing nodes have no children listed, and a nil or empty val builds an
+// empty tree.
+func BuildTreeNullable[T any](val []*T) (root *Node[T]) {
+	if len(val) == 0 || val[0] == nil {
+		return nil
+	}
+	root = NewNode(*val[0])
+	queue := []*Node[T]{root}
+	for i := 1; i < len(val) && len(queue) > 0; {
+		node := queue[0]
+		queue = queue[1:]
+		if i < len(val) {
+			if val[i] != nil {
+				node.SetLeft(NewNode(*val[i]))
+				queue = append(queue, node.Left)
+			}
+			i++
+		}
+		if i < len(val) {
+			if val[i] != nil {
+				node.SetRight(NewNode(*val[i]))
+				queue = append(queue, node.Right)
+			}
+			i++
+		}
+	}
+	return
+}
+
+// Option is an optional value, used by BuildTreeOpt to mark missing nodes.
+// The zero Option is empty.
+type Option[T any] struct {
+	Val   T
+	Valid bool
+}
+
+// Some returns an Option holding val.
+func Some[T any](val T) Option[T] {
+	return Option[T]{Val: val, Valid: true}
+}
+
+// BuildTreeOpt is like BuildTreeNullable, with missing nodes marked by
+// empty Options.
+func BuildTreeOpt[T any](val []Option[T]) *Node[T] {
+	ptrs := make([]*T, len(val))
+	for i := range val {
+		if val[i].Valid {
+			ptrs[i] = &val[i].Val
+		}
+	}
+	return BuildTreeNullable(ptrs)
+}
+
+// Serialize returns the level order of the tree in the form read by
+// BuildTreeNullable, with trailing nils trimmed.
+func (n *Node[T]) Serialize() (res []*T) {
+	if n == nil {
+		return nil
+	}
+	queue := []*Node[T]{n}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == nil {
+			res = append(res, nil)
+			continue
+		}
+		val := node.Val
+		res = append(res, &val)
+		queue = append(queue, node.Left, node.Right)
+	}
+	for len(res) > 0 && res[len(res)-1] == nil {
+		res = res[:len(res)-1]
+	}
+	return
+}
+
+// MarshalJSON encodes the tree as its serialized level order, such as
+// [1,null,2,3].
+func (n *Node[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.Serialize())
+}
+
+// UnmarshalJSON decodes a level order encoded by MarshalJSON into n, which
+// becomes the root of the tree.
+func (n *Node[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var val []*T
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+	root := BuildTreeNullable(val)
+	if root == nil {
+		return errors.New("tree: cannot unmarshal an empty tree into a Node")
+	}
+	*n = Node[T]{Val: root.Val}
+	n.SetLeft(root.Left)
+	n.SetRight(root.Right)
+	return nil
+}
+
+// DrawTree renders the tree with box-drawing characters, one node per line
+// and each child indented below its parent, left child first:
+//
+//	0
+//	├── 1
+//	│   ├── 3
+//	│   └── <nil>
+//	└── 2
+//
+// A missing child is drawn as <nil> when its sibling exists, so left and
+// right stay distinguishable.
 func (n *Node[T]) DrawTree() string {
+	if n == nil {
+		return "<nil>\n"
+	}
 	builder := strings.Builder{}
-	layer := 0
-	count := 0
-	for _, node := range n.TraversalBFS() {
-		if layer == 0 {
-			builder.WriteString(fmt.Sprintf("%v\n", node.Val))
-			layer++
-			continue
+	fmt.Fprintf(&builder, "%v\n", n.Val)
+	n.drawChildren(&builder, "")
+	return builder.String()
+}
+
+func (n *Node[T]) drawChildren(builder *strings.Builder, prefix string) {
+	if n.IsLeaf() {
+		return
+	}
+	for i, child := range [2]*Node[T]{n.Left, n.Right} {
+		branch, indent := "├── ", "│   "
+		if i == 1 {
+			branch, indent = "└── ", "    "
 		}
-		builder.WriteString(fmt.Sprintf("%v\t", node.Val))
-		count++
-		if layer*2 == count {
-			builder.WriteRune('\n')
-			layer++
-			count = 0
+		builder.WriteString(prefix)
+		builder.WriteString(branch)
+		if child == nil {
+			builder.WriteString("<nil>\n")
 			continue
 		}
+		fmt.Fprintf(builder, "%v\n", child.Val)
+		child.drawChildren(builder, prefix+indent)
 	}
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// ToDOT renders the tree in the Graphviz DOT language. Nodes are labeled
+// with their values, and a missing child of a node with one child is drawn
+// as a point, so left and right stay distinguishable.
+func (n *Node[T]) ToDOT() string {
+	builder := strings.Builder{}
+	builder.WriteString("digraph {\n")
+	if n != nil {
+		id, nils := 0, 0
+		var walk func(node *Node[T]) int
+		walk = func(node *Node[T]) int {
+			self := id
+			id++
+			fmt.Fprintf(&builder, "\tn%d [label=\"%s\"];\n", self, dotEscaper.Replace(fmt.Sprint(node.Val)))
+			if node.IsLeaf() {
+				return self
+			}
+			for _, child := range [2]*Node[T]{node.Left, node.Right} {
+				if child == nil {
+					fmt.Fprintf(&builder, "\tnil%d [shape=point];\n", nils)
+					fmt.Fprintf(&builder, "\tn%d -> nil%d;\n", self, nils)
+					nils++
+					continue
+				}
+				fmt.Fprintf(&builder, "\tn%d -> n%d;\n", self, walk(child))
+			}
+			return self
+		}
+		walk(n)
+	}
+	builder.WriteString("}\n")
 	return builder.String()
 }
 
@@ -236,6 +401,75 @@ func (n *Node[T]) TraversalDFS() (res []*Node[T]) {
 	return
 }
 
+// TraversalPreOrder returns the nodes in pre-order: each node before its
+// left subtree, then its right subtree. It is the same as TraversalDFS.
+func (n *Node[T]) TraversalPreOrder() []*Node[T] {
+	return n.walk(nil, 0)
+}
+
+// TraversalInOrder returns the nodes in in-order: each node between its
+// left and right subtrees. For a BST, this is ascending order.
+func (n *Node[T]) TraversalInOrder() []*Node[T] {
+	return n.walk(nil, 1)
+}
+
+// TraversalPostOrder returns the nodes in post-order: each node after its
+// left and right subtrees.
+func (n *Node[T]) TraversalPostOrder() []*Node[T] {
+	return n.walk(nil, 2)
+}
+
+// walk appends the nodes of the subtree to res, placing each node after
+// pos of its subtrees.
+func (n *Node[T]) walk(res []*Node[T], pos int) []*Node[T] {
+	if n == nil {
+		return res
+	}
+	if pos == 0 {
+		res = append(res, n)
+	}
+	res = n.Left.walk(res, pos)
+	if pos == 1 {
+		res = append(res, n)
+	}
+	res = n.Right.walk(res, pos)
+	if pos == 2 {
+		res = append(res, n)
+	}
+	return res
+}
+
+// Successor returns the node after n in in-order, or nil if n is the last.
+func (n *Node[T]) Successor() *Node[T] {
+	if n.Right != nil {
+		n = n.Right
+		for n.Left != nil {
+			n = n.Left
+		}
+		return n
+	}
+	for n.IsRight() {
+		n = n.Parent
+	}
+	return n.Parent
+}
+
+// Predecessor returns the node before n in in-order, or nil if n is the
+// first.
+func (n *Node[T]) Predecessor() *Node[T] {
+	if n.Left != nil {
+		n = n.Left
+		for n.Right != nil {
+			n = n.Right
+		}
+		return n
+	}
+	for n.IsLeft() {
+		n = n.Parent
+	}
+	return n.Parent
+}
+
 func (n *Node[T]) TraversalBFS() (res []*Node[T]) {
 	r := n.traversalBFS(nil, 0)
 	res = make([]*Node[T], 0, len(r)*2)
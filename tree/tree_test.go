@@ -1,7 +1,11 @@
 package tree
 
 import (
+	"encoding/json"
+	"math/rand"
 	"testing"
+
+	"golang.org/x/exp/slices"
 )
 
 func TestLayer(t *testing.T) {
@@ -272,3 +276,294 @@ func TestBuildTree(t *testing.T) {
 		t.Errorf("root's right's right value should be 6")
 	}
 }
+
+func values[T any](nodes []*Node[T]) []T {
+	res := make([]T, len(nodes))
+	for i, n := range nodes {
+		res[i] = n.Val
+	}
+	return res
+}
+
+func TestTraversalOrders(t *testing.T) {
+	root := BuildTree([]int{0, 1, 2, 3, 4, 5, 6})
+	if v := values(root.TraversalPreOrder()); !slices.Equal(v, []int{0, 1, 3, 4, 2, 5, 6}) {
+		t.Errorf("pre-order should be [0 1 3 4 2 5 6], but got %v", v)
+	}
+	if v := values(root.TraversalInOrder()); !slices.Equal(v, []int{3, 1, 4, 0, 5, 2, 6}) {
+		t.Errorf("in-order should be [3 1 4 0 5 2 6], but got %v", v)
+	}
+	if v := values(root.TraversalPostOrder()); !slices.Equal(v, []int{3, 4, 1, 5, 6, 2, 0}) {
+		t.Errorf("post-order should be [3 4 1 5 6 2 0], but got %v", v)
+	}
+	if v := values(root.TraversalDFS()); !slices.Equal(v, values(root.TraversalPreOrder())) {
+		t.Errorf("TraversalDFS should be pre-order, but got %v", v)
+	}
+
+	var in []int
+	for n := root.Left.Left; n != nil; n = n.Successor() {
+		in = append(in, n.Val)
+	}
+	if !slices.Equal(in, []int{3, 1, 4, 0, 5, 2, 6}) {
+		t.Errorf("successors should be [3 1 4 0 5 2 6], but got %v", in)
+	}
+	in = in[:0]
+	for n := root.Right.Right; n != nil; n = n.Predecessor() {
+		in = append(in, n.Val)
+	}
+	if !slices.Equal(in, []int{6, 2, 5, 0, 4, 1, 3}) {
+		t.Errorf("predecessors should be [6 2 5 0 4 1 3], but got %v", in)
+	}
+}
+
+// checkAVL checks the links, order, sizes and balance of the subtree and
+// returns its height.
+func checkAVL(t *testing.T, n *Node[int]) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	if n.Left != nil && (n.Left.Parent != n || n.Left.Val >= n.Val) {
+		t.Fatalf("bad left child of %d", n.Val)
+	}
+	if n.Right != nil && (n.Right.Parent != n || n.Right.Val <= n.Val) {
+		t.Fatalf("bad right child of %d", n.Val)
+	}
+	l, r := checkAVL(t, n.Left), checkAVL(t, n.Right)
+	if l-r > 1 || r-l > 1 {
+		t.Fatalf("node %d is unbalanced: %d vs %d", n.Val, l, r)
+	}
+	if n.size != size(n.Left)+size(n.Right)+1 {
+		t.Fatalf("node %d has size %d", n.Val, n.size)
+	}
+	if n.height != max(l, r)+1 {
+		t.Fatalf("node %d has height %d", n.Val, n.height)
+	}
+	return n.height
+}
+
+func TestBST(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	b := NewOrderedBST[int]()
+	var model []int
+	for i := 0; i < 5000; i++ {
+		v := r.Intn(1000)
+		j, found := slices.BinarySearch(model, v)
+		if r.Intn(3) > 0 {
+			_, inserted := b.Insert(v)
+			if inserted == found {
+				t.Fatalf("Insert(%d) should report %t", v, !found)
+			}
+			if !found {
+				model = slices.Insert(model, j, v)
+			}
+		} else {
+			if b.Delete(v) != found {
+				t.Fatalf("Delete(%d) should report %t", v, found)
+			}
+			if found {
+				model = slices.Delete(model, j, j+1)
+			}
+		}
+		if b.Root() != nil && b.Root().Parent != nil {
+			t.Fatalf("root has a parent")
+		}
+		checkAVL(t, b.Root())
+	}
+	if b.Len() != len(model) {
+		t.Fatalf("length should be %d, but got %d", len(model), b.Len())
+	}
+	if s := b.Slice(); !slices.Equal(s, model) {
+		t.Fatalf("values should be %v, but got %v", model, s)
+	}
+	if v := values(b.Root().TraversalInOrder()); !slices.Equal(v, model) {
+		t.Fatalf("in-order should be %v, but got %v", model, v)
+	}
+	if b.Min().Val != model[0] || b.Max().Val != model[len(model)-1] {
+		t.Errorf("min and max should be %d and %d", model[0], model[len(model)-1])
+	}
+	for i, v := range model {
+		if n := b.Select(i); n == nil || n.Val != v {
+			t.Fatalf("Select(%d) should be %d", i, v)
+		}
+		if rank := b.Rank(v); rank != i {
+			t.Fatalf("Rank(%d) should be %d, but got %d", v, i, rank)
+		}
+		if b.Find(v) == nil || b.Find(v).Val != v {
+			t.Fatalf("Find(%d) failed", v)
+		}
+	}
+	if b.Select(-1) != nil || b.Select(len(model)) != nil {
+		t.Errorf("Select out of range should be nil")
+	}
+	for v := -1; v <= 1000; v++ {
+		j, found := slices.BinarySearch(model, v)
+		if n := b.Successor(v); j+btoi(found) < len(model) {
+			if n == nil || n.Val != model[j+btoi(found)] {
+				t.Fatalf("Successor(%d) should be %d", v, model[j+btoi(found)])
+			}
+		} else if n != nil {
+			t.Fatalf("Successor(%d) should be nil, but got %d", v, n.Val)
+		}
+		if n := b.Predecessor(v); j > 0 {
+			if n == nil || n.Val != model[j-1] {
+				t.Fatalf("Predecessor(%d) should be %d", v, model[j-1])
+			}
+		} else if n != nil {
+			t.Fatalf("Predecessor(%d) should be nil, but got %d", v, n.Val)
+		}
+		if b.Contain(v) != found {
+			t.Fatalf("Contain(%d) should be %t", v, found)
+		}
+	}
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestBSTComparator(t *testing.T) {
+	b := NewBST(func(a, b string) int {
+		return len(a) - len(b)
+	})
+	for _, s := range []string{"ccc", "a", "bb", "dddd", "e"} {
+		b.Insert(s)
+	}
+	if s := b.Slice(); !slices.Equal(s, []string{"a", "bb", "ccc", "dddd"}) {
+		t.Errorf("values should be [a bb ccc dddd], but got %v", s)
+	}
+	n := b.Find("xx")
+	b.DeleteNode(n)
+	if b.Len() != 3 || b.Contain("bb") {
+		t.Errorf("bb should be deleted")
+	}
+}
+
+func TestDrawTree(t *testing.T) {
+	root := BuildTree([]int{0, 1, 2, 3, 4, 5})
+	root.Left.SetRight(nil)
+	root.Right.Left.SetLeft(NewNode(10))
+	want := `0
+├── 1
+│   ├── 3
+│   └── <nil>
+└── 2
+    ├── 5
+    │   ├── 10
+    │   └── <nil>
+    └── <nil>
+`
+	if s := root.DrawTree(); s != want {
+		t.Errorf("DrawTree should be\n%s\nbut got\n%s", want, s)
+	}
+	var empty *Node[int]
+	if s := empty.DrawTree(); s != "<nil>\n" {
+		t.Errorf("DrawTree of nil should be <nil>, but got %q", s)
+	}
+}
+
+func TestToDOT(t *testing.T) {
+	root := BuildTree([]string{`a"b`, "c"})
+	want := `digraph {
+	n0 [label="a\"b"];
+	n1 [label="c"];
+	n0 -> n1;
+	nil0 [shape=point];
+	n0 -> nil0;
+}
+`
+	if s := root.ToDOT(); s != want {
+		t.Errorf("ToDOT should be\n%s\nbut got\n%s", want, s)
+	}
+}
+
+func ptrs(val ...any) []*int {
+	res := make([]*int, len(val))
+	for i, v := range val {
+		if v != nil {
+			x := v.(int)
+			res[i] = &x
+		}
+	}
+	return res
+}
+
+func TestBuildTreeNullable(t *testing.T) {
+	root := BuildTreeNullable(ptrs(1, nil, 2, 3))
+	if root.Val != 1 || root.Left != nil || root.Right.Val != 2 || root.Right.Left.Val != 3 {
+		t.Errorf("tree should be [1,null,2,3], but got\n%s", root.DrawTree())
+	}
+	if root.Right.Left.Parent != root.Right {
+		t.Errorf("parent links should be set")
+	}
+	if BuildTreeNullable(ptrs(nil, 1)) != nil || BuildTreeNullable[int](nil) != nil {
+		t.Errorf("tree with a nil root should be nil")
+	}
+
+	opt := BuildTreeOpt([]Option[int]{Some(1), {}, Some(2), Some(3)})
+	if s, _ := json.Marshal(opt); string(s) != "[1,null,2,3]" {
+		t.Errorf("BuildTreeOpt should build [1,null,2,3], but got %s", s)
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	for _, s := range []string{
+		"[1]",
+		"[1,2,3]",
+		"[1,null,2,3]",
+		"[5,4,7,3,null,2,null,-1,null,9]",
+		"[1,2,3,4,5,6,7,8]",
+	} {
+		var val []*int
+		if err := json.Unmarshal([]byte(s), &val); err != nil {
+			t.Fatal(err)
+		}
+		root := BuildTreeNullable(val)
+		b, err := json.Marshal(root.Serialize())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != s {
+			t.Errorf("Serialize should be %s, but got %s", s, b)
+		}
+	}
+	var empty *Node[int]
+	if empty.Serialize() != nil {
+		t.Errorf("Serialize of nil should be nil")
+	}
+}
+
+func TestNodeJSON(t *testing.T) {
+	type doc struct {
+		Tree  *Node[int] `json:"tree"`
+		Empty *Node[int] `json:"empty"`
+	}
+	in := doc{Tree: BuildTreeNullable(ptrs(1, nil, 2, 3))}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"tree":[1,null,2,3],"empty":null}` {
+		t.Errorf("unexpected JSON %s", b)
+	}
+	var out doc
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Empty != nil {
+		t.Errorf("empty tree should decode as nil")
+	}
+	if out.Tree.DrawTree() != in.Tree.DrawTree() {
+		t.Errorf("decoded tree should be\n%s\nbut got\n%s", in.Tree.DrawTree(), out.Tree.DrawTree())
+	}
+	if out.Tree.Right.Parent != out.Tree {
+		t.Errorf("decoded root's children should link to it")
+	}
+	var n Node[int]
+	if err := json.Unmarshal([]byte("[]"), &n); err == nil {
+		t.Errorf("decoding an empty tree into a Node should fail")
+	}
+}